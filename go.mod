@@ -4,6 +4,10 @@ go 1.16
 
 require (
 	github.com/davidji99/rollrest-go v0.1.7
+	github.com/davidji99/simpleresty v0.2.3
+	github.com/go-resty/resty/v2 v2.2.0
+	github.com/hashicorp/go-cty v1.4.1-0.20200414143053-d3edf31b6320
+	github.com/hashicorp/terraform-plugin-log v0.3.0
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.13.0
 	github.com/stretchr/testify v1.7.1
 )
@@ -0,0 +1,123 @@
+package rollbar
+
+import (
+	"context"
+	"fmt"
+	"github.com/davidji99/rollrest-go/rollrest"
+	"github.com/davidji99/simpleresty"
+	"net/http"
+)
+
+// The API client does not support every endpoint used by the provider.
+// The functions in this file call these endpoints directly with the provider's HTTP client.
+
+// projectUpdateRequest represents a request to update a project.
+type projectUpdateRequest struct {
+	Name string `json:"name,omitempty"`
+}
+
+// projectSettings represents the settings of a project managed by the rollbar_project_settings resource.
+//
+// Only settings that are set are sent when updating a project's settings.
+type projectSettings struct {
+	Timezone            *string   `json:"timezone,omitempty"`
+	DefaultEnvironment  *string   `json:"default_environment,omitempty"`
+	IPAddressCollection *string   `json:"ip_address_collection,omitempty"`
+	ScrubFields         *[]string `json:"scrub_fields,omitempty"`
+	SamplingRate        *float64  `json:"occurrence_sampling_rate,omitempty"`
+}
+
+// projectSettingsRequest represents a request to update a project's settings.
+type projectSettingsRequest struct {
+	SettingsData *projectSettings `json:"settings_data"`
+}
+
+// projectSettingsResponse represents the response returned after retrieving or updating a project's settings.
+//
+// It also contains the project's metadata, so a project and its settings can be read with a single request.
+type projectSettingsResponse struct {
+	ErrorCount *int `json:"err,omitempty"`
+	Result     *struct {
+		ID           *int64           `json:"id,omitempty"`
+		AccountID    *int64           `json:"account_id,omitempty"`
+		Status       *string          `json:"status,omitempty"`
+		DateCreated  *int64           `json:"date_created,omitempty"`
+		DateModified *int64           `json:"date_modified,omitempty"`
+		Name         *string          `json:"name,omitempty"`
+		SettingsData *projectSettings `json:"settings_data,omitempty"`
+	} `json:"result,omitempty"`
+}
+
+// projectAccessTokenUpdateRequest represents a request to update a project access token.
+//
+// Unlike rollrest.PATUpdateRequest, it can also update the token's status.
+type projectAccessTokenUpdateRequest struct {
+	Status               string `json:"status,omitempty"`
+	RateLimitWindowSize  int    `json:"rate_limit_window_size,omitempty"`
+	RateLimitWindowCount int    `json:"rate_limit_window_count,omitempty"`
+}
+
+// request executes an API request authenticated with the given access token
+// and decodes the response into result.
+func (c *Config) request(ctx context.Context, token, method, path string, body,
+	result interface{}) (*simpleresty.Response, error) {
+	req := c.http.ConstructRequest(result, body).
+		SetContext(ctx).
+		SetHeader(rollrest.RollbarAuthHeader, token)
+	req.Method = method
+	req.URL = c.http.RequestURL(path)
+
+	return c.http.Dispatch(req)
+}
+
+// updateProject updates an existing project.
+//
+// Rollbar API docs: https://explorer.docs.rollbar.com/#operation/update-a-project
+func (c *Config) updateProject(ctx context.Context, projectID int,
+	opts *projectUpdateRequest) (*rollrest.ProjectResponse, *simpleresty.Response, error) {
+	var result *rollrest.ProjectResponse
+
+	response, err := c.request(ctx, c.accountAccessToken, http.MethodPatch,
+		fmt.Sprintf("/project/%d", projectID), opts, &result)
+
+	return result, response, err
+}
+
+// getProjectSettings retrieves a project's settings.
+//
+// Rollbar API docs: https://explorer.docs.rollbar.com/#operation/get-a-project
+func (c *Config) getProjectSettings(ctx context.Context,
+	projectID int) (*projectSettingsResponse, *simpleresty.Response, error) {
+	var result *projectSettingsResponse
+
+	response, err := c.request(ctx, c.accountAccessToken, http.MethodGet,
+		fmt.Sprintf("/project/%d", projectID), nil, &result)
+
+	return result, response, err
+}
+
+// updateProjectSettings updates a project's settings.
+//
+// Rollbar API docs: https://explorer.docs.rollbar.com/#operation/update-a-project
+func (c *Config) updateProjectSettings(ctx context.Context, projectID int,
+	opts *projectSettingsRequest) (*projectSettingsResponse, *simpleresty.Response, error) {
+	var result *projectSettingsResponse
+
+	response, err := c.request(ctx, c.accountAccessToken, http.MethodPatch,
+		fmt.Sprintf("/project/%d", projectID), opts, &result)
+
+	return result, response, err
+}
+
+// updateProjectAccessToken updates a project access token.
+//
+// Rollbar API docs: https://explorer.docs.rollbar.com/#operation/update-a-rate-limit
+func (c *Config) updateProjectAccessToken(ctx context.Context, projectID int, accessToken string,
+	opts *projectAccessTokenUpdateRequest) (*rollrest.ProjectAccessTokenResponse, *simpleresty.Response, error) {
+	var result *rollrest.ProjectAccessTokenResponse
+
+	response, err := c.request(ctx, c.accountAccessToken, http.MethodPatch,
+		fmt.Sprintf("/project/%d/access_token/%s", projectID, accessToken), opts, &result)
+
+	return result, response, err
+}
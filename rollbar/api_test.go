@@ -0,0 +1,59 @@
+package rollbar
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestConfigUpdateProject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/project/123", r.URL.Path)
+		assert.Equal(t, "account-token", r.Header.Get("x-rollbar-access-token"))
+
+		var body map[string]interface{}
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "renamed", body["name"])
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"err": 0, "result": {"id": 123, "name": "renamed"}}`))
+	}))
+	defer server.Close()
+
+	config := newTestConfig(t, server.URL)
+
+	project, _, err := config.updateProject(context.Background(), 123, &projectUpdateRequest{Name: "renamed"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "renamed", project.GetResult().GetName())
+}
+
+func TestConfigUpdateProjectSettings_OnlySetSettings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+
+		var body map[string]map[string]interface{}
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]interface{}{"timezone": "UTC", "scrub_fields": []interface{}{}},
+			body["settings_data"])
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"err": 0, "result": {"id": 123, "name": "foobar", "settings_data": {"timezone": "UTC"}}}`))
+	}))
+	defer server.Close()
+
+	config := newTestConfig(t, server.URL)
+	timezone := "UTC"
+	scrubFields := make([]string, 0)
+
+	project, _, err := config.updateProjectSettings(context.Background(), 123, &projectSettingsRequest{
+		SettingsData: &projectSettings{Timezone: &timezone, ScrubFields: &scrubFields},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "UTC", *project.Result.SettingsData.Timezone)
+}
@@ -0,0 +1,116 @@
+package rollbar
+
+import (
+	"context"
+	"github.com/davidji99/rollrest-go/rollrest"
+	"sync"
+)
+
+// lookupCache memoizes account wide listings for the duration of a Terraform run so lookups performed by
+// many resources do not download the same list over and over again.
+//
+// Resources that change a listing must invalidate it after a successful API call.
+type lookupCache struct {
+	disabled bool
+	users    cachedList
+	projects cachedList
+	teams    cachedList
+}
+
+// cachedList is a single memoized listing. Concurrent callers wait for the first load to complete
+// instead of all retrieving the listing at the same time.
+type cachedList struct {
+	mu     sync.Mutex
+	value  interface{}
+	loaded bool
+}
+
+// get returns the cached listing or loads it if the listing is not cached.
+func (l *cachedList) get(disabled bool, load func() (interface{}, error)) (interface{}, error) {
+	if disabled {
+		return load()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.loaded {
+		return l.value, nil
+	}
+
+	value, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	l.value = value
+	l.loaded = true
+
+	return value, nil
+}
+
+// invalidate removes the cached listing so the next get loads it again.
+func (l *cachedList) invalidate() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.value = nil
+	l.loaded = false
+}
+
+// listUsers returns all users in the account.
+func (c *Config) listUsers(ctx context.Context) ([]*rollrest.User, error) {
+	users, err := c.cache.users.get(c.cache.disabled, func() (interface{}, error) {
+		return c.listAllUsers(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return users.([]*rollrest.User), nil
+}
+
+// listProjects returns all non-deleted projects in the account.
+func (c *Config) listProjects(ctx context.Context) ([]*rollrest.Project, error) {
+	projects, err := c.cache.projects.get(c.cache.disabled, func() (interface{}, error) {
+		all, listErr := c.listAllProjects(ctx)
+		if listErr != nil {
+			return nil, listErr
+		}
+
+		// Deleted projects are returned by the API but without a name.
+		var projects []*rollrest.Project
+		for _, project := range all {
+			if project.GetName() != "" {
+				projects = append(projects, project)
+			}
+		}
+		return projects, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return projects.([]*rollrest.Project), nil
+}
+
+// listTeams returns all teams in the account.
+func (c *Config) listTeams(ctx context.Context) ([]*rollrest.Team, error) {
+	teams, err := c.cache.teams.get(c.cache.disabled, func() (interface{}, error) {
+		client, clientInitErr := c.apiClient(ctx)
+		if clientInitErr != nil {
+			return nil, clientInitErr
+		}
+
+		result, _, listErr := client.Teams.List()
+		if listErr != nil {
+			return nil, listErr
+		}
+		return result.Result, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return teams.([]*rollrest.Team), nil
+}
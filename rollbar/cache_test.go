@@ -0,0 +1,67 @@
+package rollbar
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCachedList_LoadsOnce(t *testing.T) {
+	var list cachedList
+	var loads int32
+
+	load := func() (interface{}, error) {
+		atomic.AddInt32(&loads, 1)
+		return []string{"a"}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := list.get(false, load)
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"a"}, value)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), loads)
+
+	list.invalidate()
+	list.get(false, load)
+	assert.Equal(t, int32(2), loads)
+}
+
+func TestCachedList_Disabled(t *testing.T) {
+	var list cachedList
+	var loads int32
+
+	load := func() (interface{}, error) {
+		atomic.AddInt32(&loads, 1)
+		return nil, nil
+	}
+
+	list.get(true, load)
+	list.get(true, load)
+
+	assert.Equal(t, int32(2), loads)
+}
+
+func TestCachedList_ErrorNotCached(t *testing.T) {
+	var list cachedList
+
+	_, err := list.get(false, func() (interface{}, error) {
+		return nil, errors.New("boom")
+	})
+	assert.NotNil(t, err)
+
+	value, err := list.get(false, func() (interface{}, error) {
+		return "ok", nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "ok", value)
+}
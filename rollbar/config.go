@@ -1,39 +1,200 @@
 package rollbar
 
 import (
+	"context"
 	"fmt"
 	"github.com/davidji99/rollrest-go/rollrest"
+	"github.com/davidji99/simpleresty"
 	"github.com/davidji99/terraform-provider-rollbar/version"
+	"github.com/go-resty/resty/v2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// requestTimeout is the timeout for a single attempt of an API request.
+	requestTimeout = 1 * time.Minute
 )
 
 type Config struct {
 	API                                       *rollrest.Client
+	http                                      *simpleresty.Client
+	transport                                 http.RoundTripper
+	userAgent                                 string
+	BaseURL                                   string
 	Headers                                   map[string]string
+	MaxRetries                                int
+	RetryMaxWait                              time.Duration
+	RequestsPerMinute                         int
+	MaxConcurrentRequests                     int
+	SkipCredentialsValidation                 bool
 	accountAccessToken                        string
 	projectAccessToken                        string
+	projectAccessTokens                       map[int]string
+	FetchProjectAccessTokens                  bool
 	PostCreatePDIntegrationDeleteDefaultRules bool
+	ProtectAllProjects                        bool
+
+	// cache memoizes user, project and team listings used for lookups.
+	cache *lookupCache
+
+	// fetchedProjectAccessTokens caches the access tokens retrieved for projects when fetch_project_access_tokens
+	// is enabled.
+	fetchedProjectAccessTokens   map[int]string
+	fetchedProjectAccessTokensMu sync.Mutex
 }
 
 func NewConfig() *Config {
-	config := &Config{}
+	config := &Config{
+		BaseURL:      rollrest.DefaultAPIBaseURL,
+		MaxRetries:   DefaultMaxRetries,
+		RetryMaxWait: DefaultRetryMaxWait * time.Second,
+		userAgent:    fmt.Sprintf("terraform-provider-rollbar/v%s", version.ProviderVersion),
+		cache:        &lookupCache{},
+	}
 	return config
 }
 
-func (c *Config) initializeAPI() error {
-	userAgent := fmt.Sprintf("terraform-provider-rollbar/v%s", version.ProviderVersion)
+func (c *Config) initializeAPI(ctx context.Context) error {
+	// Every attempt of a retried request is throttled, so the retry transport wraps the throttle transport.
+	// The transport is shared by all API clients so throttling applies across every resource.
+	throttle := newThrottleTransport(newLoggingTransport(ctx, http.DefaultTransport), c.RequestsPerMinute,
+		c.MaxConcurrentRequests)
+	c.transport = newRetryTransport(ctx, throttle, c.MaxRetries, c.RetryMaxWait)
+	c.fetchedProjectAccessTokens = make(map[int]string)
 
-	api, clientInitErr := rollrest.New(rollrest.AuthAAT(c.accountAccessToken), rollrest.AuthPAT(c.projectAccessToken),
-		rollrest.CustomHTTPHeaders(c.Headers), rollrest.UserAgent(userAgent))
+	api, httpClient, clientInitErr := c.newClient(context.Background(), c.accountAccessToken, c.projectAccessToken)
 	if clientInitErr != nil {
 		return clientInitErr
 	}
 	c.API = api
+	c.http = httpClient
 
 	return nil
 }
 
-func (c *Config) applySchema(d *schema.ResourceData) (err error) {
+// newClient returns a new API client authenticated with the given access tokens.
+//
+// Each API client needs its own HTTP client as the authentication header is set on the HTTP client itself.
+// The API client does not accept a context, so requests made without one are bound to ctx instead.
+func (c *Config) newClient(ctx context.Context, accountAccessToken,
+	projectAccessToken string) (*rollrest.Client, *simpleresty.Client, error) {
+	httpClient := simpleresty.New()
+	httpClient.SetTransport(c.transport)
+	httpClient.OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
+		if req.Context() == context.Background() {
+			req.SetContext(ctx)
+		}
+		return nil
+	})
+
+	api, clientInitErr := rollrest.New(rollrest.HTTP(httpClient), rollrest.AuthAAT(accountAccessToken),
+		rollrest.AuthPAT(projectAccessToken), rollrest.BaseURL(c.BaseURL), rollrest.CustomHTTPHeaders(c.Headers),
+		rollrest.UserAgent(c.userAgent))
+	if clientInitErr != nil {
+		return nil, nil, clientInitErr
+	}
+
+	// The HTTP client timeout covers all retries of a request, so it needs to account for every attempt
+	// as well as the maximum wait time between them.
+	httpClient.SetTimeout(time.Duration(c.MaxRetries+1)*requestTimeout + time.Duration(c.MaxRetries)*c.RetryMaxWait)
+
+	return api, httpClient, nil
+}
+
+// apiClient returns an API client using the provider's access tokens whose requests are cancelled with ctx.
+func (c *Config) apiClient(ctx context.Context) (*rollrest.Client, error) {
+	client, _, clientInitErr := c.newClient(ctx, c.accountAccessToken, c.projectAccessToken)
+	return client, clientInitErr
+}
+
+// projectClient returns the API client for a project whose requests are cancelled with ctx.
+//
+// If projectID is zero, the client uses the provider's project_access_token.
+// Otherwise, the client uses the project's token from project_access_tokens or, if fetch_project_access_tokens
+// is enabled, an enabled token with the write scope retrieved with the account access token.
+func (c *Config) projectClient(ctx context.Context, projectID int) (*rollrest.Client, error) {
+	if projectID == 0 {
+		return c.apiClient(ctx)
+	}
+
+	token, tokenErr := c.projectAccessTokenFor(ctx, projectID)
+	if tokenErr != nil {
+		return nil, tokenErr
+	}
+
+	client, _, clientInitErr := c.newClient(ctx, c.accountAccessToken, token)
+	return client, clientInitErr
+}
+
+// projectAccessTokenFor returns the access token to use for a project.
+func (c *Config) projectAccessTokenFor(ctx context.Context, projectID int) (string, error) {
+	if token, ok := c.projectAccessTokens[projectID]; ok {
+		return token, nil
+	}
+
+	if !c.FetchProjectAccessTokens {
+		return "", fmt.Errorf("no access token configured for project %d. Please add the project to "+
+			"project_access_tokens or enable fetch_project_access_tokens", projectID)
+	}
+
+	c.fetchedProjectAccessTokensMu.Lock()
+	defer c.fetchedProjectAccessTokensMu.Unlock()
+
+	if token, ok := c.fetchedProjectAccessTokens[projectID]; ok {
+		return token, nil
+	}
+
+	token, fetchErr := c.fetchProjectAccessToken(ctx, projectID)
+	if fetchErr != nil {
+		return "", fetchErr
+	}
+	c.fetchedProjectAccessTokens[projectID] = token
+
+	return token, nil
+}
+
+// fetchProjectAccessToken retrieves an enabled project access token with the write scope using the account access token.
+//
+// Tokens neutralized with rate limits by the provider stay enabled but are skipped, as almost every request made
+// with them is rate limited. If several tokens qualify, the most recently created one is used.
+func (c *Config) fetchProjectAccessToken(ctx context.Context, projectID int) (string, error) {
+	if c.accountAccessToken == "" {
+		return "", fmt.Errorf("fetching the access token for project %d requires account_access_token", projectID)
+	}
+
+	pats, _, listErr := c.listProjectAccessTokens(ctx, projectID)
+	if listErr != nil {
+		return "", listErr
+	}
+
+	candidates := make([]*rollrest.ProjectAccessToken, 0)
+	for _, pat := range pats {
+		if pat.GetStatus() == "enabled" && Contains(pat.Scopes, "write") && !isNeutralizedProjectAccessToken(pat) {
+			candidates = append(candidates, pat)
+		}
+	}
+
+	if pat := newestProjectAccessToken(candidates); pat != nil {
+		registerSecret(pat.GetAccessToken())
+		return pat.GetAccessToken(), nil
+	}
+
+	return "", fmt.Errorf("could not find an enabled access token with the write scope for project %d", projectID)
+}
+
+func (c *Config) applySchema(ctx context.Context, d *schema.ResourceData) (err error) {
+	if v, ok := d.GetOk("api_url"); ok {
+		// The API client does not accept a base URL with a trailing slash.
+		vs := strings.TrimRight(v.(string), "/")
+		logDebug(ctx, "api_url to be used", map[string]interface{}{"api_url": vs})
+		c.BaseURL = vs
+	}
+
 	if v, ok := d.GetOk("headers"); ok {
 		headersRaw := v.(map[string]interface{})
 		h := make(map[string]string)
@@ -45,7 +206,36 @@ func (c *Config) applySchema(d *schema.ResourceData) (err error) {
 		c.Headers = h
 	}
 
+	c.MaxRetries = d.Get("max_retries").(int)
+	c.RetryMaxWait = time.Duration(d.Get("retry_max_wait").(int)) * time.Second
+
+	c.RequestsPerMinute = d.Get("requests_per_minute").(int)
+	c.MaxConcurrentRequests = d.Get("max_concurrent_requests").(int)
+
+	c.SkipCredentialsValidation = d.Get("skip_credentials_validation").(bool)
+
+	if v, ok := d.GetOk("project_access_tokens"); ok {
+		tokens := make(map[int]string)
+
+		for projectID, token := range v.(map[string]interface{}) {
+			id, parseErr := strconv.Atoi(projectID)
+			if parseErr != nil {
+				return fmt.Errorf("project_access_tokens keys must be project IDs: %s", projectID)
+			}
+
+			tokens[id] = token.(string)
+			registerSecret(token.(string))
+		}
+
+		c.projectAccessTokens = tokens
+	}
+
+	c.FetchProjectAccessTokens = d.Get("fetch_project_access_tokens").(bool)
+
+	c.cache.disabled = d.Get("disable_lookup_cache").(bool)
+
 	c.PostCreatePDIntegrationDeleteDefaultRules = d.Get("post_create_pd_integration_delete_default_rules").(bool)
+	c.ProtectAllProjects = d.Get("protect_all_projects").(bool)
 
 	return nil
 }
@@ -0,0 +1,114 @@
+package rollbar
+
+import (
+	"context"
+	"errors"
+	"github.com/davidji99/rollrest-go/rollrest"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func newTestConfig(t *testing.T, baseURL string) *Config {
+	config := NewConfig()
+	config.BaseURL = baseURL
+	config.MaxRetries = 0
+	config.accountAccessToken = "account-token"
+
+	if err := config.initializeAPI(context.Background()); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	return config
+}
+
+func TestConfigAPIClient_CancelledContext(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+	}))
+	defer server.Close()
+
+	config := newTestConfig(t, server.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client, err := config.apiClient(ctx)
+	assert.Nil(t, err)
+
+	_, _, err = client.Teams.List()
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, int32(0), requests)
+}
+
+func TestConfigProjectClient_ConfiguredToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "project-token", r.Header.Get(rollrest.RollbarAuthHeader))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := newTestConfig(t, server.URL)
+	config.projectAccessTokens = map[int]string{123: "project-token"}
+
+	client, err := config.projectClient(context.Background(), 123)
+	assert.Nil(t, err)
+
+	_, err = client.Notifications.ConfigurePagerDutyIntegration(&rollrest.PDIntegrationRequest{})
+	assert.Nil(t, err)
+
+	_, err = config.projectClient(context.Background(), 456)
+	assert.NotNil(t, err)
+}
+
+func TestConfigProjectClient_FetchedToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/project/123/access_tokens", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"err": 0, "result": [
+			{"project_id": 123, "name": "read", "access_token": "read-token", "status": "enabled", "scopes": ["read"]},
+			{"project_id": 123, "name": "write", "access_token": "write-token", "status": "enabled", "scopes": ["write"]}
+		]}`))
+	}))
+	defer server.Close()
+
+	config := newTestConfig(t, server.URL)
+	config.FetchProjectAccessTokens = true
+
+	token, err := config.fetchProjectAccessToken(context.Background(), 123)
+	assert.Nil(t, err)
+	assert.Equal(t, "write-token", token)
+
+	_, err = config.projectClient(context.Background(), 123)
+	assert.Nil(t, err)
+	assert.Equal(t, "write-token", config.fetchedProjectAccessTokens[123])
+}
+
+func TestConfigFetchProjectAccessToken_SkipsNeutralizedTokens(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"err": 0, "result": [
+			{"project_id": 123, "name": "write", "access_token": "neutralized-token", "status": "enabled",
+				"scopes": ["write"], "rate_limit_window_count": 1, "rate_limit_window_size": 2592000,
+				"date_created": 1600000200},
+			{"project_id": 123, "name": "deploy", "access_token": "old-token", "status": "enabled",
+				"scopes": ["read", "write"], "date_created": 1600000000},
+			{"project_id": 123, "name": "deploy", "access_token": "new-token", "status": "enabled",
+				"scopes": ["read", "write"], "rate_limit_window_count": 500, "rate_limit_window_size": 60,
+				"date_created": 1600000100},
+			{"project_id": 123, "name": "disabled", "access_token": "disabled-token", "status": "disabled",
+				"scopes": ["write"], "date_created": 1600000300}
+		]}`))
+	}))
+	defer server.Close()
+
+	config := newTestConfig(t, server.URL)
+
+	token, err := config.fetchProjectAccessToken(context.Background(), 123)
+	assert.Nil(t, err)
+	assert.Equal(t, "new-token", token)
+}
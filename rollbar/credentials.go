@@ -0,0 +1,187 @@
+package rollbar
+
+import (
+	"context"
+	"fmt"
+	"github.com/davidji99/rollrest-go/rollrest"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"net/http"
+)
+
+const (
+	// accountTokenProbePath is an inexpensive endpoint that requires an account access token with the read scope.
+	accountTokenProbePath = "/projects"
+
+	// projectTokenProbePath is an inexpensive endpoint that requires a project access token with the read scope.
+	projectTokenProbePath = "/items"
+)
+
+// accessTokenCheck describes how a configured access token is validated.
+type accessTokenCheck struct {
+	attribute      string
+	token          string
+	probePath      string
+	otherAttribute string
+	otherProbePath string
+}
+
+// validateCredentials calls an inexpensive endpoint with each configured access token to verify that the token
+// is valid, is of the expected type and has the read scope.
+//
+// The write scope cannot be verified without modifying data, so it is only logged as unverified.
+func (c *Config) validateCredentials(ctx context.Context) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if c.accountAccessToken != "" {
+		diags = append(diags, c.validateAccessToken(ctx, &accessTokenCheck{
+			attribute:      "account_access_token",
+			token:          c.accountAccessToken,
+			probePath:      accountTokenProbePath,
+			otherAttribute: "project_access_token",
+			otherProbePath: projectTokenProbePath,
+		})...)
+	}
+
+	if c.projectAccessToken != "" {
+		diags = append(diags, c.validateAccessToken(ctx, &accessTokenCheck{
+			attribute:      "project_access_token",
+			token:          c.projectAccessToken,
+			probePath:      projectTokenProbePath,
+			otherAttribute: "account_access_token",
+			otherProbePath: accountTokenProbePath,
+		})...)
+	}
+
+	return diags
+}
+
+// validateAccessToken validates a single access token.
+//
+// Rollbar returns a 401 for an unknown access token and a 403 for a token that is missing a required scope.
+// If the token is rejected, it is probed against the endpoint for the other token type to detect tokens that
+// were set on the wrong provider argument.
+func (c *Config) validateAccessToken(ctx context.Context, check *accessTokenCheck) diag.Diagnostics {
+	path := cty.GetAttrPath(check.attribute)
+
+	status, probeErr := c.probeAccessToken(ctx, check.token, check.probePath)
+	if probeErr != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Warning,
+			Summary:       fmt.Sprintf("Unable to validate %s", check.attribute),
+			Detail:        redact(probeErr.Error()),
+			AttributePath: path,
+		}}
+	}
+
+	switch status {
+	case http.StatusOK:
+		logDebug(ctx, fmt.Sprintf("%s is valid and has the read scope. The write scope cannot be verified "+
+			"without modifying data", check.attribute))
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		if otherStatus, otherErr := c.probeAccessToken(ctx, check.token, check.otherProbePath); otherErr == nil &&
+			otherStatus == http.StatusOK {
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("%s is not the expected type of access token", check.attribute),
+				Detail: fmt.Sprintf("The token set for %s is accepted as a %s. Please set it as %s instead.",
+					check.attribute, check.otherAttribute, check.otherAttribute),
+				AttributePath: path,
+			}}
+		}
+
+		if status == http.StatusUnauthorized {
+			return diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("%s is invalid", check.attribute),
+				Detail:        "Rollbar did not accept the access token. Please verify the token exists and is enabled.",
+				AttributePath: path,
+			}}
+		}
+
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%s does not have the read scope", check.attribute),
+			Detail: "The access token is valid but is missing the read scope. " +
+				"Resources that need to read data from Rollbar will fail. " +
+				"Please use a token with both the read and write scopes.",
+			AttributePath: path,
+		}}
+	default:
+		return diag.Diagnostics{{
+			Severity:      diag.Warning,
+			Summary:       fmt.Sprintf("Unable to validate %s", check.attribute),
+			Detail:        fmt.Sprintf("Rollbar returned an unexpected status code: %d", status),
+			AttributePath: path,
+		}}
+	}
+}
+
+// probeAccessToken makes a GET request with the access token and returns the response status code.
+func (c *Config) probeAccessToken(ctx context.Context, token, path string) (int, error) {
+	resp, err := c.http.R().
+		SetContext(ctx).
+		SetHeader(rollrest.RollbarAuthHeader, token).
+		Get(c.http.RequestURL(path))
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.StatusCode(), nil
+}
+
+// requireAccountAccessToken returns a CustomizeDiffFunc that fails the plan
+// if the provider is not configured with an account access token.
+func requireAccountAccessToken(resourceType string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, _ *schema.ResourceDiff, meta interface{}) error {
+		return checkAccountAccessToken(resourceType, meta)
+	}
+}
+
+// checkAccountAccessToken returns an error if the provider is not configured with an account access token.
+func checkAccountAccessToken(resourceType string, meta interface{}) error {
+	if meta.(*Config).accountAccessToken == "" {
+		return fmt.Errorf("%s requires an account access token. Please set account_access_token "+
+			"in the provider configuration or the ROLLBAR_ACCOUNT_ACCESS_TOKEN environment variable", resourceType)
+	}
+	return nil
+}
+
+// requireProjectAccessToken returns a CustomizeDiffFunc that fails the plan
+// if the provider is not configured with an access token for the resource's project.
+//
+// Resources without a project_id use the provider's project_access_token.
+func requireProjectAccessToken(resourceType string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		config := meta.(*Config)
+
+		// The project ID is not known until apply if it references a project created in the same run.
+		// It has to be checked first as GetOk does not report unknown values as set.
+		if !d.NewValueKnown("project_id") {
+			return nil
+		}
+
+		projectID, ok := d.GetOk("project_id")
+		if !ok {
+			if config.projectAccessToken == "" {
+				return fmt.Errorf("%s requires a project access token. Please set project_access_token "+
+					"in the provider configuration or the ROLLBAR_PROJECT_ACCESS_TOKEN environment variable", resourceType)
+			}
+			return nil
+		}
+
+		if _, ok := config.projectAccessTokens[projectID.(int)]; ok {
+			return nil
+		}
+
+		if config.FetchProjectAccessTokens && config.accountAccessToken != "" {
+			return nil
+		}
+
+		return fmt.Errorf("%s requires an access token for project %d. Please add the project to "+
+			"project_access_tokens or enable fetch_project_access_tokens with an account_access_token",
+			resourceType, projectID.(int))
+	}
+}
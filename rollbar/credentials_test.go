@@ -0,0 +1,122 @@
+package rollbar
+
+import (
+	"context"
+	"github.com/davidji99/rollrest-go/rollrest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newTestCredentialsServer returns a server that accepts the account token on the account endpoint
+// and the project token on the project endpoint.
+func newTestCredentialsServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get(rollrest.RollbarAuthHeader)
+
+		switch {
+		case r.URL.Path == "/api/1"+accountTokenProbePath && token == "account-token":
+			w.WriteHeader(http.StatusOK)
+		case r.URL.Path == "/api/1"+projectTokenProbePath && token == "project-token":
+			w.WriteHeader(http.StatusOK)
+		case r.URL.Path == "/api/1"+projectTokenProbePath && token == "post-only-token":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+}
+
+func newTestCredentialsConfig(t *testing.T, baseURL, accountToken, projectToken string) *Config {
+	config := NewConfig()
+	config.BaseURL = baseURL + "/api/1"
+	config.MaxRetries = 0
+	config.accountAccessToken = accountToken
+	config.projectAccessToken = projectToken
+
+	if err := config.initializeAPI(context.Background()); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	return config
+}
+
+func TestValidateCredentials_Valid(t *testing.T) {
+	server := newTestCredentialsServer()
+	defer server.Close()
+
+	config := newTestCredentialsConfig(t, server.URL, "account-token", "project-token")
+
+	assert.Empty(t, config.validateCredentials(context.Background()))
+}
+
+func TestValidateCredentials_Invalid(t *testing.T) {
+	server := newTestCredentialsServer()
+	defer server.Close()
+
+	config := newTestCredentialsConfig(t, server.URL, "bad-token", "")
+	diags := config.validateCredentials(context.Background())
+
+	assert.True(t, diags.HasError())
+	assert.Equal(t, "account_access_token is invalid", diags[0].Summary)
+}
+
+func TestValidateCredentials_SwappedTokens(t *testing.T) {
+	server := newTestCredentialsServer()
+	defer server.Close()
+
+	config := newTestCredentialsConfig(t, server.URL, "project-token", "account-token")
+	diags := config.validateCredentials(context.Background())
+
+	assert.Len(t, diags, 2)
+	assert.Equal(t, "account_access_token is not the expected type of access token", diags[0].Summary)
+	assert.Equal(t, "project_access_token is not the expected type of access token", diags[1].Summary)
+}
+
+func TestValidateCredentials_MissingReadScope(t *testing.T) {
+	server := newTestCredentialsServer()
+	defer server.Close()
+
+	config := newTestCredentialsConfig(t, server.URL, "", "post-only-token")
+	diags := config.validateCredentials(context.Background())
+
+	assert.False(t, diags.HasError())
+	assert.Equal(t, "project_access_token does not have the read scope", diags[0].Summary)
+}
+
+func TestCheckAccountAccessToken(t *testing.T) {
+	assert.NotNil(t, checkAccountAccessToken("rollbar_team", &Config{projectAccessToken: "project-token"}))
+	assert.Nil(t, checkAccountAccessToken("rollbar_team", &Config{accountAccessToken: "account-token"}))
+}
+
+// unknownVariableValue is how the SDK represents values that are not known until apply in a raw resource config.
+const unknownVariableValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+func TestRequireProjectAccessToken_UnknownProjectID(t *testing.T) {
+	config := &Config{accountAccessToken: "account-token", FetchProjectAccessTokens: true}
+
+	diff := func(projectID interface{}) error {
+		raw := map[string]interface{}{
+			"service_key": "abcdefghijklmnopqrstuvwxyz123456",
+			"enabled":     true,
+		}
+		if projectID != nil {
+			raw["project_id"] = projectID
+		}
+
+		_, err := resourceRollbarPagerDutyIntegration().SimpleDiff(context.Background(), nil,
+			terraform.NewResourceConfigRaw(raw), config)
+		return err
+	}
+
+	// The project ID references a project created in the same run.
+	assert.Nil(t, diff(unknownVariableValue))
+	assert.Nil(t, diff(123))
+
+	err := diff(nil)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "rollbar_pagerduty_integration requires a project access token")
+	}
+}
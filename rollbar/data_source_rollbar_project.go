@@ -4,6 +4,11 @@ import (
 	"context"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 )
 
 func dataSourceRollbarProject() *schema.Resource {
@@ -11,13 +16,18 @@ func dataSourceRollbarProject() *schema.Resource {
 		ReadContext: dataSourceRollbarProjectRead,
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
 			},
 
 			"id": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[0-9]+$`), "Must be a project ID"),
 			},
 
 			"status": {
@@ -29,33 +39,169 @@ func dataSourceRollbarProject() *schema.Resource {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+
+			"date_created": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"date_modified": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"settings": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"include_team_ids": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"team_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
 		},
 	}
 }
 
-func dataSourceRollbarProjectRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*Config).API
-	name := d.Get("name").(string)
+func dataSourceRollbarProjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := checkAccountAccessToken("rollbar_project", m); err != nil {
+		return diag.FromErr(err)
+	}
+
+	config := m.(*Config)
+
+	var projectID int
+	if v, ok := d.GetOk("id"); ok {
+		projectID = StringToInt(v.(string))
+	} else {
+		name := d.Get("name").(string)
 
-	result, _, err := client.Projects.List()
+		project, err := findProjectByName(ctx, config, name)
+		if err != nil {
+			return diag.FromErr(err)
+		}
 
-	if err != nil {
-		diag.FromErr(err)
+		if project == nil {
+			return diag.Errorf("no matches found for project name: %s", name)
+		}
+
+		projectID = int(project.GetID())
 	}
 
-	if result.HasResult() {
-		for _, project := range result.Result {
-			if project.GetName() == name {
-				d.SetId(Int64ToString(project.GetID()))
+	// The project endpoint returns the project's metadata and settings, so a single request is enough.
+	project, response, getErr := config.getProjectSettings(ctx, projectID)
+	if isNotFound(response, getErr) || (getErr == nil && (project.Result == nil || project.Result.Name == nil ||
+		*project.Result.Name == "")) {
+		return diag.Errorf("no matches found for project id: %d", projectID)
+	}
 
-				d.Set("status", project.GetStatus())
-				d.Set("account_id", project.GetAccountID())
-				d.Set("name", project.GetName())
+	if getErr != nil {
+		return diag.FromErr(getErr)
+	}
 
-				return nil
-			}
+	// Checking which teams have access to the project requires one request per team, so it is opt-in.
+	teamIDs := make([]int, 0)
+	if d.Get("include_team_ids").(bool) {
+		var teamsErr error
+		teamIDs, teamsErr = getProjectTeamIDs(ctx, config, projectID)
+		if teamsErr != nil {
+			return diag.FromErr(teamsErr)
 		}
 	}
 
-	return diag.Errorf("no matches found for project name: %s", name)
+	d.SetId(strconv.Itoa(projectID))
+
+	result := project.Result
+	d.Set("name", *result.Name)
+	if result.Status != nil {
+		d.Set("status", *result.Status)
+	}
+	if result.AccountID != nil {
+		d.Set("account_id", *result.AccountID)
+	}
+	if result.DateCreated != nil {
+		d.Set("date_created", *result.DateCreated)
+	}
+	if result.DateModified != nil {
+		d.Set("date_modified", *result.DateModified)
+	}
+	d.Set("settings", getProjectSettingsSummary(result.SettingsData))
+	d.Set("team_ids", teamIDs)
+
+	return nil
+}
+
+// getProjectSettingsSummary returns the settings of a project managed by rollbar_project_settings as strings.
+//
+// Settings that are not set are omitted.
+func getProjectSettingsSummary(settings *projectSettings) map[string]string {
+	summary := make(map[string]string)
+	if settings == nil {
+		return summary
+	}
+
+	if settings.Timezone != nil {
+		summary["timezone"] = *settings.Timezone
+	}
+
+	if settings.DefaultEnvironment != nil {
+		summary["default_environment"] = *settings.DefaultEnvironment
+	}
+
+	if settings.IPAddressCollection != nil {
+		summary["ip_address_collection"] = *settings.IPAddressCollection
+	}
+
+	if settings.ScrubFields != nil {
+		summary["scrub_fields"] = strings.Join(*settings.ScrubFields, ",")
+	}
+
+	if settings.SamplingRate != nil {
+		summary["occurrence_sampling_rate"] = strconv.FormatFloat(*settings.SamplingRate, 'f', -1, 64)
+	}
+
+	return summary
+}
+
+// getProjectTeamIDs returns the IDs of the teams that have access to a project.
+//
+// As the API cannot list the teams of a project, every team in the account is checked for access to the project.
+func getProjectTeamIDs(ctx context.Context, config *Config, projectID int) ([]int, error) {
+	teams, listErr := config.listTeams(ctx)
+	if listErr != nil {
+		return nil, listErr
+	}
+
+	client, clientErr := config.apiClient(ctx)
+	if clientErr != nil {
+		return nil, clientErr
+	}
+
+	teamIDs := make([]int, 0)
+	for _, team := range teams {
+		hasProject, response, err := client.Teams.HasProject(int(team.GetID()), projectID)
+		if isNotFound(response, err) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if hasProject {
+			teamIDs = append(teamIDs, int(team.GetID()))
+		}
+	}
+
+	sort.Ints(teamIDs)
+
+	return teamIDs, nil
 }
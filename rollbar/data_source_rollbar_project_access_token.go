@@ -0,0 +1,152 @@
+package rollbar
+
+import (
+	"context"
+	"github.com/davidji99/rollrest-go/rollrest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceRollbarProjectAccessToken() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRollbarProjectAccessTokenRead,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				AtLeastOneOf: []string{"name", "scope"},
+			},
+
+			"scope": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				AtLeastOneOf: []string{"name", "scope"},
+				ValidateFunc: validation.StringInSlice(
+					[]string{"read", "write", "post_server_item", "post_client_item"}, false),
+			},
+
+			"status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"enabled", "disabled"}, false),
+			},
+
+			"scopes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"rate_limit_window_size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"rate_limit_window_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"cur_rate_limit_window_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"date_created": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"access_token": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func dataSourceRollbarProjectAccessTokenRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := checkAccountAccessToken("rollbar_project_access_token", m); err != nil {
+		return diag.FromErr(err)
+	}
+
+	projectID := getProjectID(ctx, d)
+
+	pats, _, listErr := m.(*Config).listProjectAccessTokens(ctx, projectID)
+	if listErr != nil {
+		return diag.FromErr(listErr)
+	}
+
+	name := d.Get("name").(string)
+	scope := d.Get("scope").(string)
+	status := d.Get("status").(string)
+
+	matches := filterProjectAccessTokens(pats, name, scope, status)
+
+	if len(matches) == 0 {
+		return diag.Errorf("no project access token found in project %d matching name %q, scope %q and status %q",
+			projectID, name, scope, status)
+	}
+
+	// Tokens with the same name, such as rotated tokens, are resolved to the newest token.
+	// Tokens with different names cannot be told apart.
+	for _, match := range matches {
+		if match.GetName() != matches[0].GetName() {
+			return diag.Errorf("found %d project access tokens with different names in project %d matching "+
+				"name %q, scope %q and status %q. Please narrow down the search with name, scope or status",
+				len(matches), projectID, name, scope, status)
+		}
+	}
+
+	pat := newestProjectAccessToken(matches)
+	registerSecret(pat.GetAccessToken())
+
+	// Token names cannot be changed, so the ID is stable across reads.
+	d.SetId(projectAccessTokenID(projectID, pat.GetName()))
+
+	d.Set("name", pat.GetName())
+	d.Set("status", pat.GetStatus())
+	d.Set("scopes", pat.Scopes)
+	d.Set("rate_limit_window_size", pat.GetRateLimitWindowSize())
+	d.Set("rate_limit_window_count", pat.GetRateLimitWindowCount())
+	d.Set("cur_rate_limit_window_count", pat.GetCurrentRateLimitWindowCount())
+	d.Set("date_created", int(pat.GetDataCreated()))
+	d.Set("access_token", pat.GetAccessToken())
+
+	return nil
+}
+
+// filterProjectAccessTokens returns the project access tokens with the given name, scope and status.
+//
+// Empty filters match every token.
+func filterProjectAccessTokens(pats []*rollrest.ProjectAccessToken, name, scope,
+	status string) []*rollrest.ProjectAccessToken {
+	matches := make([]*rollrest.ProjectAccessToken, 0)
+
+	for _, pat := range pats {
+		if name != "" && pat.GetName() != name {
+			continue
+		}
+
+		if scope != "" && !Contains(pat.Scopes, scope) {
+			continue
+		}
+
+		if status != "" && pat.GetStatus() != status {
+			continue
+		}
+
+		matches = append(matches, pat)
+	}
+
+	return matches
+}
@@ -0,0 +1,134 @@
+package rollbar
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAccDatasourceRollbarProjectAccessTokenSingle_Basic(t *testing.T) {
+	projectName := fmt.Sprintf("project-tftest-%s", acctest.RandString(10))
+	tokenName := fmt.Sprintf("token-tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckRollbarProjectAccessTokenWithSingleDatasource(projectName, tokenName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.rollbar_project_access_token.by_name", "id",
+						"rollbar_project_access_token.foobar", "id"),
+					resource.TestCheckResourceAttrPair(
+						"data.rollbar_project_access_token.by_name", "access_token",
+						"rollbar_project_access_token.foobar", "access_token"),
+					resource.TestCheckResourceAttr(
+						"data.rollbar_project_access_token.by_name", "status", "disabled"),
+					resource.TestCheckResourceAttr(
+						"data.rollbar_project_access_token.by_name", "rate_limit_window_size", "60"),
+					resource.TestCheckResourceAttr(
+						"data.rollbar_project_access_token.by_scope", "name", "post_client_item"),
+					resource.TestCheckResourceAttrSet(
+						"data.rollbar_project_access_token.by_scope", "access_token"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceRollbarProjectAccessTokenRead(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"err": 0, "result": [
+			{"project_id": 1, "name": "read", "access_token": "read-token", "status": "enabled", "scopes": ["read"]},
+			{"project_id": 1, "name": "deploy", "access_token": "deploy-token", "status": "disabled",
+				"scopes": ["read", "write"], "rate_limit_window_size": 60, "rate_limit_window_count": 100,
+				"cur_rate_limit_window_count": 5, "date_created": 1600000000},
+			{"project_id": 1, "name": "rotated", "access_token": "rotated-token-1", "status": "enabled",
+				"scopes": ["post_server_item"], "date_created": 1600000000},
+			{"project_id": 1, "name": "rotated", "access_token": "rotated-token-2", "status": "enabled",
+				"scopes": ["post_server_item"], "date_created": 1600000100}
+		]}`))
+	}))
+	defer server.Close()
+
+	config := newTestConfig(t, server.URL)
+
+	d := schema.TestResourceDataRaw(t, dataSourceRollbarProjectAccessToken().Schema, map[string]interface{}{
+		"project_id": 1,
+		"scope":      "write",
+	})
+
+	diags := dataSourceRollbarProjectAccessTokenRead(context.Background(), d, config)
+
+	assert.False(t, diags.HasError())
+	assert.Equal(t, "1:deploy", d.Id())
+	assert.Equal(t, "deploy", d.Get("name"))
+	assert.Equal(t, "disabled", d.Get("status"))
+	assert.Equal(t, []interface{}{"read", "write"}, d.Get("scopes"))
+	assert.Equal(t, 60, d.Get("rate_limit_window_size"))
+	assert.Equal(t, 100, d.Get("rate_limit_window_count"))
+	assert.Equal(t, 5, d.Get("cur_rate_limit_window_count"))
+	assert.Equal(t, 1600000000, d.Get("date_created"))
+	assert.Equal(t, "deploy-token", d.Get("access_token"))
+
+	d = schema.TestResourceDataRaw(t, dataSourceRollbarProjectAccessToken().Schema, map[string]interface{}{
+		"project_id": 1,
+		"scope":      "read",
+	})
+
+	diags = dataSourceRollbarProjectAccessTokenRead(context.Background(), d, config)
+
+	if assert.True(t, diags.HasError()) {
+		assert.Contains(t, diags[0].Summary, "found 2 project access tokens with different names in project 1")
+	}
+
+	d = schema.TestResourceDataRaw(t, dataSourceRollbarProjectAccessToken().Schema, map[string]interface{}{
+		"project_id": 1,
+		"name":       "rotated",
+	})
+
+	diags = dataSourceRollbarProjectAccessTokenRead(context.Background(), d, config)
+
+	assert.False(t, diags.HasError())
+	assert.Equal(t, "1:rotated", d.Id())
+	assert.Equal(t, "rotated-token-2", d.Get("access_token"))
+}
+
+func testAccCheckRollbarProjectAccessTokenWithSingleDatasource(projectName, tokenName string) string {
+	return fmt.Sprintf(`
+resource "rollbar_project" "foobar" {
+	name = "%s"
+	deletion_protection = false
+}
+
+resource "rollbar_project_access_token" "foobar" {
+	project_id = rollbar_project.foobar.id
+	name = "%s"
+	scopes = ["read"]
+	status = "disabled"
+	rate_limit_window_size = 60
+	rate_limit_window_count = 1500
+}
+
+data "rollbar_project_access_token" "by_name" {
+	project_id = rollbar_project.foobar.id
+	name = rollbar_project_access_token.foobar.name
+}
+
+data "rollbar_project_access_token" "by_scope" {
+	project_id = rollbar_project.foobar.id
+	scope = "post_client_item"
+	status = "enabled"
+}
+`, projectName, tokenName)
+}
@@ -1,46 +1,152 @@
 package rollbar
 
-import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+import (
+	"context"
+	"github.com/davidji99/rollrest-go/rollrest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"sort"
+	"strconv"
+)
 
 func dataSourceRollbarProjectAccessTokens() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceRollbarProjectAccessTokensRead,
+		ReadContext: dataSourceRollbarProjectAccessTokensRead,
 		Schema: map[string]*schema.Schema{
 			"project_id": {
 				Type:     schema.TypeInt,
 				Required: true,
 			},
 
+			"status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"enabled", "disabled"}, false),
+			},
+
+			"scope": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice(
+					[]string{"read", "write", "post_server_item", "post_client_item"}, false),
+			},
+
 			"access_tokens": {
 				Type:      schema.TypeMap,
 				Sensitive: true,
 				Computed:  true,
 				Elem:      schema.TypeString,
 			},
+
+			"tokens": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"scopes": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"rate_limit_window_size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"rate_limit_window_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"cur_rate_limit_window_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"date_created": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"access_token": {
+							Type:      schema.TypeString,
+							Computed:  true,
+							Sensitive: true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
-func dataSourceRollbarProjectAccessTokensRead(d *schema.ResourceData, m interface{}) error {
-	d.SetId(GenerateRandomResourceID())
+func dataSourceRollbarProjectAccessTokensRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := checkAccountAccessToken("rollbar_project_access_tokens", m); err != nil {
+		return diag.FromErr(err)
+	}
 
-	client := m.(*Config).API
-	projectID := getProjectID(d)
+	projectID := getProjectID(ctx, d)
 
-	result, _, getErr := client.ProjectAccessTokens.List(projectID)
+	pats, _, getErr := m.(*Config).listProjectAccessTokens(ctx, projectID)
 	if getErr != nil {
-		return getErr
+		return diag.FromErr(getErr)
 	}
 
+	pats = filterProjectAccessTokens(pats, "", d.Get("scope").(string), d.Get("status").(string))
+
+	sort.SliceStable(pats, func(i, j int) bool {
+		if pats[i].GetName() != pats[j].GetName() {
+			return pats[i].GetName() < pats[j].GetName()
+		}
+		return pats[i].GetDataCreated() < pats[j].GetDataCreated()
+	})
+
 	// Loop through and create a map of string:string, where the key is the token name
 	// and the value is the access token.
 	tokenMap := make(map[string]string)
-	for _, pat := range result.Result {
+	tokens := make([]map[string]interface{}, 0, len(pats))
+	for _, pat := range pats {
+		registerSecret(pat.GetAccessToken())
+		tokens = append(tokens, flattenProjectAccessToken(pat))
+
 		// Only store enabled tokens
 		if pat.GetStatus() == "enabled" {
 			tokenMap[pat.GetName()] = pat.GetAccessToken()
 		}
 	}
 
-	return d.Set("access_tokens", tokenMap)
+	// The ID only depends on the project so it does not change between reads.
+	d.SetId(strconv.Itoa(projectID))
+
+	if setErr := d.Set("access_tokens", tokenMap); setErr != nil {
+		return diag.FromErr(setErr)
+	}
+
+	return diag.FromErr(d.Set("tokens", tokens))
+}
+
+// flattenProjectAccessToken converts a project access token to the attributes of a token in the tokens list.
+func flattenProjectAccessToken(pat *rollrest.ProjectAccessToken) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                        pat.GetName(),
+		"status":                      pat.GetStatus(),
+		"scopes":                      pat.Scopes,
+		"rate_limit_window_size":      pat.GetRateLimitWindowSize(),
+		"rate_limit_window_count":     pat.GetRateLimitWindowCount(),
+		"cur_rate_limit_window_count": int(pat.GetCurrentRateLimitWindowCount()),
+		"date_created":                int(pat.GetDataCreated()),
+		"access_token":                pat.GetAccessToken(),
+	}
 }
@@ -1,9 +1,14 @@
 package rollbar
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -27,20 +32,83 @@ func TestAccDatasourceRollbarProjectAccessToken_Basic(t *testing.T) {
 						"data.rollbar_project_access_tokens.foobar", "access_tokens.read"),
 					resource.TestCheckResourceAttrSet(
 						"data.rollbar_project_access_tokens.foobar", "access_tokens.write"),
+					resource.TestCheckResourceAttr(
+						"data.rollbar_project_access_tokens.foobar", "tokens.#", "4"),
+					resource.TestCheckResourceAttr(
+						"data.rollbar_project_access_tokens.write", "tokens.#", "1"),
+					resource.TestCheckResourceAttr(
+						"data.rollbar_project_access_tokens.write", "tokens.0.name", "write"),
 				),
 			},
 		},
 	})
 }
 
+func TestDataSourceRollbarProjectAccessTokensRead(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"err": 0, "result": [
+			{"project_id": 1, "name": "deploy", "access_token": "new-token", "status": "enabled",
+				"scopes": ["write"], "date_created": 1600000200},
+			{"project_id": 1, "name": "deploy", "access_token": "old-token", "status": "enabled",
+				"scopes": ["write"], "date_created": 1600000100},
+			{"project_id": 1, "name": "ci", "access_token": "ci-token", "status": "disabled",
+				"scopes": ["read", "write"], "rate_limit_window_size": 60, "rate_limit_window_count": 100},
+			{"project_id": 1, "name": "read", "access_token": "read-token", "status": "enabled", "scopes": ["read"]}
+		]}`))
+	}))
+	defer server.Close()
+
+	config := newTestConfig(t, server.URL)
+
+	d := schema.TestResourceDataRaw(t, dataSourceRollbarProjectAccessTokens().Schema, map[string]interface{}{
+		"project_id": 1,
+		"scope":      "write",
+	})
+
+	diags := dataSourceRollbarProjectAccessTokensRead(context.Background(), d, config)
+
+	assert.False(t, diags.HasError())
+	assert.Equal(t, "1", d.Id())
+	assert.Equal(t, map[string]interface{}{"deploy": "new-token"}, d.Get("access_tokens"))
+
+	tokens := d.Get("tokens").([]interface{})
+	if assert.Len(t, tokens, 3) {
+		assert.Equal(t, "ci", tokens[0].(map[string]interface{})["name"])
+		assert.Equal(t, "disabled", tokens[0].(map[string]interface{})["status"])
+		assert.Equal(t, []interface{}{"read", "write"}, tokens[0].(map[string]interface{})["scopes"])
+		assert.Equal(t, 60, tokens[0].(map[string]interface{})["rate_limit_window_size"])
+		assert.Equal(t, "old-token", tokens[1].(map[string]interface{})["access_token"])
+		assert.Equal(t, "new-token", tokens[2].(map[string]interface{})["access_token"])
+	}
+
+	d = schema.TestResourceDataRaw(t, dataSourceRollbarProjectAccessTokens().Schema, map[string]interface{}{
+		"project_id": 1,
+		"status":     "disabled",
+	})
+
+	diags = dataSourceRollbarProjectAccessTokensRead(context.Background(), d, config)
+
+	assert.False(t, diags.HasError())
+	assert.Len(t, d.Get("tokens").([]interface{}), 1)
+	assert.Empty(t, d.Get("access_tokens"))
+}
+
 func testAccCheckRollbarProjectAccessTokenWithDatasourceBasic(projectName string) string {
 	return fmt.Sprintf(`
 resource "rollbar_project" "foobar" {
 	name = "%s"
+	deletion_protection = false
 }
 
 data "rollbar_project_access_tokens" "foobar" {
   project_id = rollbar_project.foobar.id
 }
+
+data "rollbar_project_access_tokens" "write" {
+  project_id = rollbar_project.foobar.id
+  scope = "write"
+  status = "enabled"
+}
 `, projectName)
 }
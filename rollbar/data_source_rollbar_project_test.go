@@ -1,9 +1,14 @@
 package rollbar
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -33,10 +38,93 @@ func TestAccDatasourceRollbarProject_Basic(t *testing.T) {
 	})
 }
 
+func TestAccDatasourceRollbarProject_ByID(t *testing.T) {
+	name := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckRollbarProjectWithDatasourceByID(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.rollbar_project.foobar", "name", name),
+					resource.TestCheckResourceAttrSet(
+						"data.rollbar_project.foobar", "date_created"),
+					resource.TestCheckResourceAttr(
+						"data.rollbar_project.foobar", "team_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceRollbarProjectRead_ByID(t *testing.T) {
+	requests := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/project/123":
+			w.Write([]byte(`{"err": 0, "result": {"id": 123, "name": "foobar", "status": "enabled", "account_id": 1,
+				"date_created": 1600000000, "settings_data": {"timezone": "UTC", "scrub_fields": ["password", "secret"]}}}`))
+		case "/teams":
+			w.Write([]byte(`{"err": 0, "result": [{"id": 2}, {"id": 1}]}`))
+		case "/team/1/project/123":
+			w.Write([]byte(`{"err": 0, "result": {"team_id": 1, "project_id": 123}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	config := newTestConfig(t, server.URL)
+
+	d := schema.TestResourceDataRaw(t, dataSourceRollbarProject().Schema, map[string]interface{}{"id": "123"})
+
+	diags := dataSourceRollbarProjectRead(context.Background(), d, config)
+
+	assert.False(t, diags.HasError())
+	assert.Equal(t, "foobar", d.Get("name"))
+	assert.Equal(t, 1600000000, d.Get("date_created"))
+	assert.Equal(t, map[string]interface{}{"timezone": "UTC", "scrub_fields": "password,secret"}, d.Get("settings"))
+	assert.Equal(t, []interface{}{}, d.Get("team_ids"))
+	assert.Equal(t, map[string]int{"/project/123": 1}, requests)
+
+	d = schema.TestResourceDataRaw(t, dataSourceRollbarProject().Schema, map[string]interface{}{
+		"id":               "123",
+		"include_team_ids": true,
+	})
+
+	diags = dataSourceRollbarProjectRead(context.Background(), d, config)
+
+	assert.False(t, diags.HasError())
+	assert.Equal(t, []interface{}{1}, d.Get("team_ids"))
+}
+
+func testAccCheckRollbarProjectWithDatasourceByID(projectName string) string {
+	return fmt.Sprintf(`
+resource "rollbar_project" "foobar" {
+	name = "%s"
+	deletion_protection = false
+}
+
+data "rollbar_project" "foobar" {
+  id = rollbar_project.foobar.id
+  include_team_ids = true
+}
+`, projectName)
+}
+
 func testAccCheckRollbarProjectWithDatasourceBasic(projectName string) string {
 	return fmt.Sprintf(`
 resource "rollbar_project" "foobar" {
 	name = "%s"
+	deletion_protection = false
 }
 
 data "rollbar_project" "foobar" {
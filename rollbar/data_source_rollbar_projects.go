@@ -0,0 +1,109 @@
+package rollbar
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"regexp"
+	"sort"
+)
+
+func dataSourceRollbarProjects() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRollbarProjectsRead,
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+
+			"status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"projects": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"account_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRollbarProjectsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := checkAccountAccessToken("rollbar_projects", m); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(v.(string))
+	}
+	status := d.Get("status").(string)
+
+	projects, err := m.(*Config).listProjects(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	matches := make([]map[string]interface{}, 0)
+	for _, project := range projects {
+		if nameRegex != nil && !nameRegex.MatchString(project.GetName()) {
+			continue
+		}
+
+		if status != "" && project.GetStatus() != status {
+			continue
+		}
+
+		matches = append(matches, map[string]interface{}{
+			"id":         int(project.GetID()),
+			"name":       project.GetName(),
+			"status":     project.GetStatus(),
+			"account_id": int(project.GetAccountID()),
+		})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i]["id"].(int) < matches[j]["id"].(int)
+	})
+
+	d.SetId(projectsDataSourceID(d.Get("name_regex").(string), status))
+
+	return diag.FromErr(d.Set("projects", matches))
+}
+
+// projectsDataSourceID returns an ID derived from the filters, so it does not change between reads.
+func projectsDataSourceID(nameRegex, status string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("name_regex=%q,status=%q", nameRegex, status)))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,96 @@
+package rollbar
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAccDatasourceRollbarProjects_Basic(t *testing.T) {
+	name := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckRollbarProjectsWithDatasourceBasic(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.rollbar_projects.foobar", "projects.#", "1"),
+					resource.TestCheckResourceAttr(
+						"data.rollbar_projects.foobar", "projects.0.name", name),
+					resource.TestCheckResourceAttrPair(
+						"data.rollbar_projects.foobar", "projects.0.id", "rollbar_project.foobar", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceRollbarProjectsRead_Filters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"err": 0, "result": [
+			{"id": 3, "name": "prod-web", "status": "enabled", "account_id": 1},
+			{"id": 1, "name": "prod-api", "status": "enabled", "account_id": 1},
+			{"id": 2, "name": "prod-old", "status": "disabled", "account_id": 1},
+			{"id": 4, "name": "staging-api", "status": "enabled", "account_id": 1},
+			{"id": 5, "name": "", "status": "enabled", "account_id": 1}
+		]}`))
+	}))
+	defer server.Close()
+
+	config := newTestConfig(t, server.URL)
+
+	d := schema.TestResourceDataRaw(t, dataSourceRollbarProjects().Schema, map[string]interface{}{
+		"name_regex": "^prod-",
+		"status":     "enabled",
+	})
+
+	diags := dataSourceRollbarProjectsRead(context.Background(), d, config)
+
+	assert.False(t, diags.HasError())
+	assert.Equal(t, 2, d.Get("projects.#"))
+	assert.Equal(t, "prod-api", d.Get("projects.0.name"))
+	assert.Equal(t, "prod-web", d.Get("projects.1.name"))
+
+	id := d.Id()
+	assert.False(t, dataSourceRollbarProjectsRead(context.Background(), d, config).HasError())
+	assert.Equal(t, id, d.Id())
+	assert.NotEqual(t, id, projectsDataSourceID("^prod-", ""))
+}
+
+func TestDataSourceRollbarProjectsRead_ListError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	config := newTestConfig(t, server.URL)
+
+	d := schema.TestResourceDataRaw(t, dataSourceRollbarProjects().Schema, map[string]interface{}{})
+
+	assert.True(t, dataSourceRollbarProjectsRead(context.Background(), d, config).HasError())
+}
+
+func testAccCheckRollbarProjectsWithDatasourceBasic(projectName string) string {
+	return fmt.Sprintf(`
+resource "rollbar_project" "foobar" {
+	name = "%s"
+	deletion_protection = false
+}
+
+data "rollbar_projects" "foobar" {
+  name_regex = "^${rollbar_project.foobar.name}$"
+}
+`, projectName)
+}
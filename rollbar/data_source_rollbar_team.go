@@ -1,10 +1,14 @@
 package rollbar
 
-import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
 
 func dataSourceRollbarTeam() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceRollbarTeamRead,
+		ReadContext: dataSourceRollbarTeamRead,
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:     schema.TypeString,
@@ -29,8 +33,21 @@ func dataSourceRollbarTeam() *schema.Resource {
 	}
 }
 
-func dataSourceRollbarTeamRead(d *schema.ResourceData, m interface{}) error {
-	d.SetId(d.Get("id").(string))
+func dataSourceRollbarTeamRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := checkAccountAccessToken("rollbar_team", m); err != nil {
+		return diag.FromErr(err)
+	}
+
+	teamID := d.Get("id").(string)
+	d.SetId(teamID)
+
+	if readDiags := resourceRollbarTeamRead(ctx, d, m); readDiags.HasError() {
+		return readDiags
+	}
+
+	if d.Id() == "" {
+		return diag.Errorf("could not find team %s", teamID)
+	}
 
-	return resourceRollbarTeamRead(d, m)
+	return nil
 }
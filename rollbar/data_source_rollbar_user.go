@@ -1,14 +1,15 @@
 package rollbar
 
 import (
-	"fmt"
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"strconv"
 )
 
 func dataSourceRollbarUser() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceRollbarUserRead,
+		ReadContext: dataSourceRollbarUserRead,
 		Schema: map[string]*schema.Schema{
 			"email": {
 				Type:     schema.TypeString,
@@ -23,17 +24,19 @@ func dataSourceRollbarUser() *schema.Resource {
 	}
 }
 
-func dataSourceRollbarUserRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Config).API
+func dataSourceRollbarUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := checkAccountAccessToken("rollbar_user", m); err != nil {
+		return diag.FromErr(err)
+	}
 
 	userEmail := d.Get("email").(string)
 
-	users, _, getErr := client.Users.List()
+	users, getErr := m.(*Config).listUsers(ctx)
 	if getErr != nil {
-		return getErr
+		return diag.FromErr(getErr)
 	}
 
-	for _, user := range users.GetResult().Users {
+	for _, user := range users {
 		if user.GetEmail() == userEmail {
 			d.SetId(strconv.FormatInt(user.GetID(), 10))
 
@@ -44,5 +47,5 @@ func dataSourceRollbarUserRead(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 
-	return fmt.Errorf("could not find user %s in this account", userEmail)
+	return diag.Errorf("could not find user %s in this account", userEmail)
 }
@@ -1,6 +1,71 @@
 package rollbar
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/davidji99/simpleresty"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"net/http"
+)
+
 // UserNotFoundError is returned when searching for a user by a certain criteria.
 type UserNotFoundError struct {
 	error
 }
+
+// NotFoundError is returned when a remote object does not exist.
+type NotFoundError struct {
+	error
+}
+
+// isNotFound returns true if the API response status or the returned error indicates
+// the requested object does not exist.
+func isNotFound(response *simpleresty.Response, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var notFoundErr NotFoundError
+	if errors.As(err, &notFoundErr) {
+		return true
+	}
+
+	return response != nil && response.StatusCode == http.StatusNotFound
+}
+
+// removeFromStateIfNotFound removes the resource from state if the object no longer exists remotely
+// so Terraform will plan to recreate it. It returns true if the resource was removed.
+//
+// This should be used by every resource's Read function after retrieving the remote object.
+func removeFromStateIfNotFound(ctx context.Context, d *schema.ResourceData, resourceType string, response *simpleresty.Response, err error) bool {
+	if !isNotFound(response, err) {
+		return false
+	}
+
+	logWarn(ctx, fmt.Sprintf("%s %s not found, removing from state", resourceType, d.Id()),
+		map[string]interface{}{"resource_type": resourceType, "id": d.Id()})
+	d.SetId("")
+
+	return true
+}
+
+// deletionProtectionDiagnostic returns the error diagnostic for deleting a protected object.
+//
+// byProvider is true if the object is protected by the provider's protect_all_projects argument.
+func deletionProtectionDiagnostic(objectType, id string, byProvider bool) diag.Diagnostic {
+	detail := fmt.Sprintf("Set deletion_protection to false and apply the change before deleting the %s.", objectType)
+	if byProvider {
+		detail = fmt.Sprintf("The provider's protect_all_projects argument prevents deleting any %s. "+
+			"Set it to false before deleting the %s.", objectType, objectType)
+	}
+
+	return diag.Diagnostic{
+		Severity:      diag.Error,
+		Summary:       fmt.Sprintf("Cannot delete %s %s as deletion protection is enabled", objectType, id),
+		Detail:        detail,
+		AttributePath: cty.GetAttrPath("deletion_protection"),
+	}
+}
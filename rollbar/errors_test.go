@@ -0,0 +1,39 @@
+package rollbar
+
+import (
+	"context"
+	"fmt"
+	"github.com/davidji99/simpleresty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestIsNotFound_ResponseStatus(t *testing.T) {
+	err := fmt.Errorf("GET /project/1: 404")
+
+	assert.True(t, isNotFound(&simpleresty.Response{StatusCode: 404}, err))
+	assert.False(t, isNotFound(&simpleresty.Response{StatusCode: 500}, err))
+	assert.False(t, isNotFound(&simpleresty.Response{StatusCode: 404}, nil))
+}
+
+func TestIsNotFound_NotFoundError(t *testing.T) {
+	err := NotFoundError{fmt.Errorf("project access token not found")}
+
+	assert.True(t, isNotFound(nil, err))
+	assert.True(t, isNotFound(nil, fmt.Errorf("wrapped: %w", err)))
+	assert.False(t, isNotFound(nil, fmt.Errorf("connection refused")))
+}
+
+func TestRemoveFromStateIfNotFound(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceRollbarProject().Schema, map[string]interface{}{})
+	d.SetId("123")
+
+	assert.False(t, removeFromStateIfNotFound(context.Background(), d, "rollbar_project", &simpleresty.Response{StatusCode: 500},
+		fmt.Errorf("internal server error")))
+	assert.Equal(t, "123", d.Id())
+
+	assert.True(t, removeFromStateIfNotFound(context.Background(), d, "rollbar_project", &simpleresty.Response{StatusCode: 404},
+		fmt.Errorf("not found")))
+	assert.Equal(t, "", d.Id())
+}
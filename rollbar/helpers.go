@@ -1,9 +1,10 @@
 package rollbar
 
 import (
+	"context"
 	"fmt"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"log"
 	"math/rand"
 	"strconv"
 	"strings"
@@ -11,11 +12,11 @@ import (
 )
 
 // getEmail extracts the email attribute generically from a Rollbar resource.
-func getEmail(d *schema.ResourceData) string {
+func getEmail(ctx context.Context, d *schema.ResourceData) string {
 	var email string
 	if v, ok := d.GetOk("email"); ok {
 		vs := v.(string)
-		log.Printf("[DEBUG] email: %s", vs)
+		logDebug(ctx, "email", map[string]interface{}{"email": vs})
 		email = vs
 	}
 
@@ -23,11 +24,11 @@ func getEmail(d *schema.ResourceData) string {
 }
 
 // getTeamID extracts the team ID attribute generically from a Rollbar resource.
-func getTeamID(d *schema.ResourceData) int {
+func getTeamID(ctx context.Context, d *schema.ResourceData) int {
 	var teamID int
 	if v, ok := d.GetOk("team_id"); ok {
 		vs := v.(int)
-		log.Printf("[DEBUG] team_id: %d", vs)
+		logDebug(ctx, "team_id", map[string]interface{}{"team_id": vs})
 		teamID = vs
 	}
 
@@ -35,17 +36,70 @@ func getTeamID(d *schema.ResourceData) int {
 }
 
 // getProjectID extracts the project ID attribute generically from a Rollbar resource.
-func getProjectID(d *schema.ResourceData) int {
+func getProjectID(ctx context.Context, d *schema.ResourceData) int {
 	var projectID int
 	if v, ok := d.GetOk("project_id"); ok {
 		vs := v.(int)
-		log.Printf("[DEBUG] Project id: %d", vs)
+		logDebug(ctx, "project_id", map[string]interface{}{"project_id": vs})
 		projectID = vs
 	}
 
 	return projectID
 }
 
+// rawConfigGetter is implemented by both schema.ResourceData and schema.ResourceDiff.
+type rawConfigGetter interface {
+	GetRawConfig() cty.Value
+	GetOk(key string) (interface{}, bool)
+}
+
+// isConfigured checks if an attribute is set in a Rollbar resource's configuration, including to its zero value.
+//
+// If the configuration is not available, only attributes with a non-zero value are considered to be set.
+func isConfigured(d rawConfigGetter, key string) bool {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		_, ok := d.GetOk(key)
+		return ok
+	}
+
+	return !rawConfig.GetAttr(key).IsNull()
+}
+
+// configuredDeleteTimeout returns the delete timeout set in the timeouts block of a resource's configuration,
+// or defaultTimeout if it is not set.
+func configuredDeleteTimeout(d rawConfigGetter, defaultTimeout time.Duration) time.Duration {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() || !rawConfig.Type().HasAttribute(schema.TimeoutsConfigKey) {
+		return defaultTimeout
+	}
+
+	timeouts := rawConfig.GetAttr(schema.TimeoutsConfigKey)
+	if timeouts.IsNull() || !timeouts.IsKnown() || !timeouts.Type().HasAttribute(schema.TimeoutDelete) {
+		return defaultTimeout
+	}
+
+	deleteTimeout := timeouts.GetAttr(schema.TimeoutDelete)
+	if deleteTimeout.IsNull() || !deleteTimeout.IsKnown() {
+		return defaultTimeout
+	}
+
+	timeout, err := time.ParseDuration(deleteTimeout.AsString())
+	if err != nil {
+		return defaultTimeout
+	}
+
+	return timeout
+}
+
+// stringValue returns the value of a string pointer or an empty string if the pointer is nil.
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
 // StringToInt converts a string parameter to an integer.
 func StringToInt(s string) int {
 	intValue, _ := strconv.Atoi(s)
@@ -99,3 +153,18 @@ func Contains(s []string, e string) bool {
 func DoesNotContain(s []string, e string) bool {
 	return !Contains(s, e)
 }
+
+// validateDuration validates that a string attribute is a non-negative duration such as "30m" or "24h".
+func validateDuration(v interface{}, k string) (ws []string, errs []error) {
+	duration, err := time.ParseDuration(v.(string))
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%q must be a duration such as 30m or 24h, got %s", k, v.(string)))
+		return
+	}
+
+	if duration < 0 {
+		errs = append(errs, fmt.Errorf("%q must not be negative, got %s", k, v.(string)))
+	}
+
+	return
+}
@@ -1,10 +1,26 @@
 package rollbar
 
 import (
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
+// testRawConfig implements rawConfigGetter with a fixed configuration.
+type testRawConfig struct {
+	rawConfig cty.Value
+}
+
+func (c *testRawConfig) GetRawConfig() cty.Value {
+	return c.rawConfig
+}
+
+func (c *testRawConfig) GetOk(key string) (interface{}, bool) {
+	return nil, false
+}
+
 func TestStringToInt_Basic(t *testing.T) {
 	assert.Equal(t, 123, StringToInt("123"))
 }
@@ -29,3 +45,31 @@ func TestParseCompositeID_Invalid(t *testing.T) {
 	_, _, parseErr := ParseCompositeImportID("hello:moto:again")
 	assert.NotNil(t, parseErr)
 }
+
+func TestValidateDuration(t *testing.T) {
+	_, errs := validateDuration("24h", "grace_period")
+	assert.Empty(t, errs)
+
+	_, errs = validateDuration("1 day", "grace_period")
+	assert.Len(t, errs, 1)
+
+	_, errs = validateDuration("-5m", "grace_period")
+	assert.Len(t, errs, 1)
+}
+
+func TestConfiguredDeleteTimeout(t *testing.T) {
+	withTimeouts := func(timeouts cty.Value) *testRawConfig {
+		return &testRawConfig{rawConfig: cty.ObjectVal(map[string]cty.Value{
+			"name":                   cty.StringVal("foobar"),
+			schema.TimeoutsConfigKey: timeouts,
+		})}
+	}
+	timeoutsType := cty.Object(map[string]cty.Type{schema.TimeoutDelete: cty.String})
+
+	assert.Equal(t, 30*time.Minute, configuredDeleteTimeout(withTimeouts(cty.ObjectVal(map[string]cty.Value{
+		schema.TimeoutDelete: cty.StringVal("30m"),
+	})), 5*time.Minute))
+	assert.Equal(t, 5*time.Minute, configuredDeleteTimeout(withTimeouts(cty.NullVal(timeoutsType)), 5*time.Minute))
+	assert.Equal(t, 5*time.Minute, configuredDeleteTimeout(&testRawConfig{rawConfig: cty.NullVal(cty.EmptyObject)},
+		5*time.Minute))
+}
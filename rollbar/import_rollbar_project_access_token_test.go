@@ -1,38 +1,89 @@
 package rollbar
 
-// TODO: comment out code after figuring out a better way to define ID for the PAT resource.
-//func TestAccRollbarProjectAccessToken_importBasic(t *testing.T) {
-//	projectName := fmt.Sprintf("project-tftest-%s", acctest.RandString(10))
-//	tokenName := fmt.Sprintf("token-tftest-%s", acctest.RandString(10))
-//
-//	resource.Test(t, resource.TestCase{
-//		PreCheck: func() {
-//			testAccPreCheck(t)
-//		},
-//		Providers: testAccProviders,
-//		Steps: []resource.TestStep{
-//			{
-//				Config: testAccCheckRollbarProjectAccessToken_basic(projectName, tokenName),
-//			},
-//			{
-//				ResourceName:            "rollbar_project_access_token.foobar",
-//				ImportStateIdFunc:       testAccRollbarProjectAccessTokenImportStateIdFunc("rollbar_project_access_token.foobar"),
-//				ImportState:             true,
-//				ImportStateVerify:       false,
-//				ImportStateVerifyIgnore: []string{"id"},
-//			},
-//		},
-//	})
-//}
-//
-//func testAccRollbarProjectAccessTokenImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
-//	return func(s *terraform.State) (string, error) {
-//		rs, ok := s.RootModule().Resources[resourceName]
-//		if !ok {
-//			return "", fmt.Errorf("Not found: %s", resourceName)
-//		}
-//
-//		return fmt.Sprintf("%s:%s", rs.Primary.Attributes["project_id"],
-//			rs.Primary.Attributes["access_token"]), nil
-//	}
-//}
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAccRollbarProjectAccessToken_importBasic(t *testing.T) {
+	projectName := fmt.Sprintf("project-tftest-%s", acctest.RandString(10))
+	tokenName := fmt.Sprintf("token-tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckRollbarProjectAccessToken_basic(projectName, tokenName),
+			},
+			{
+				ResourceName:      "rollbar_project_access_token.foobar",
+				ImportStateIdFunc: testAccRollbarProjectAccessTokenImportStateIdFunc("rollbar_project_access_token.foobar"),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccRollbarProjectAccessTokenImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		return fmt.Sprintf("%s:%s", rs.Primary.Attributes["project_id"],
+			rs.Primary.Attributes["name"]), nil
+	}
+}
+
+func TestFindProjectAccessTokenForImport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"err": 0, "result": [
+			{"project_id": 1, "name": "deploy", "access_token": "deploy-token", "status": "enabled"},
+			{"project_id": 1, "name": "ci:read", "access_token": "ci-token", "status": "enabled"},
+			{"project_id": 1, "name": "rotated", "access_token": "rotated-token-1", "status": "enabled",
+				"date_created": 1600000000},
+			{"project_id": 1, "name": "rotated", "access_token": "rotated-token-2", "status": "enabled",
+				"date_created": 1600000100},
+			{"project_id": 1, "name": "rotated", "access_token": "rotated-token-3", "status": "disabled",
+				"date_created": 1600000200}
+		]}`))
+	}))
+	defer server.Close()
+
+	config := newTestConfig(t, server.URL)
+
+	pat, err := findProjectAccessTokenForImport(context.Background(), config, 1, "ci:read")
+	assert.Nil(t, err)
+	assert.Equal(t, "ci-token", pat.GetAccessToken())
+
+	pat, err = findProjectAccessTokenForImport(context.Background(), config, 1, "deploy-token")
+	assert.Nil(t, err)
+	assert.Equal(t, "deploy", pat.GetName())
+
+	// The newest enabled token wins if several tokens have the same name.
+	pat, err = findProjectAccessTokenForImport(context.Background(), config, 1, "rotated")
+	assert.Nil(t, err)
+	assert.Equal(t, "rotated-token-2", pat.GetAccessToken())
+
+	_, err = findProjectAccessTokenForImport(context.Background(), config, 1, "missing")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "no project access token named missing found in project 1")
+	}
+}
+
+func TestProjectAccessTokenID(t *testing.T) {
+	assert.Equal(t, "1:ci:read", projectAccessTokenID(1, "ci:read"))
+}
@@ -23,6 +23,8 @@ func TestAccRollbarProject_importBasic(t *testing.T) {
 				ResourceName:      "rollbar_project.foobar",
 				ImportState:       true,
 				ImportStateVerify: true,
+				// deletion_protection is not stored remotely and defaults to true on import.
+				ImportStateVerifyIgnore: []string{"deletion_protection"},
 			},
 		},
 	})
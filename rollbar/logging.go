@@ -0,0 +1,164 @@
+package rollbar
+
+import (
+	"context"
+	"github.com/davidji99/rollrest-go/rollrest"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+	"net/http"
+	"net/http/httputil"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const (
+	// logSubsystemHTTP is the tflog subsystem for API request and response dumps.
+	logSubsystemHTTP = "rollbar_http"
+
+	// redactedValue replaces any sensitive value in log output.
+	redactedValue = "***"
+
+	// minSecretLength is the minimum length of a registered secret. Shorter values are ignored
+	// to avoid masking unrelated parts of a log message.
+	minSecretLength = 8
+)
+
+var (
+	// secrets holds every value that needs to be masked in log output.
+	secrets = &secretRegistry{values: make(map[string]struct{})}
+
+	// sensitiveHeaderRegex matches headers that contain credentials in HTTP dumps.
+	sensitiveHeaderRegex = regexp.MustCompile(`(?im)^((?:authorization|` +
+		regexp.QuoteMeta(rollrest.RollbarAuthHeader) + `):\s*).*$`)
+
+	// sensitiveFieldRegex matches JSON fields that contain credentials in HTTP dumps.
+	sensitiveFieldRegex = regexp.MustCompile(`("(?:access_token|service_key)"\s*:\s*)"[^"]*"`)
+)
+
+// secretRegistry is a concurrency safe set of sensitive values.
+type secretRegistry struct {
+	mu     sync.RWMutex
+	values map[string]struct{}
+}
+
+// registerSecret marks a value as sensitive so it is masked in all log output.
+func registerSecret(secret string) {
+	if len(secret) < minSecretLength {
+		return
+	}
+
+	secrets.mu.Lock()
+	defer secrets.mu.Unlock()
+
+	secrets.values[secret] = struct{}{}
+}
+
+// redact replaces every registered secret in s.
+func redact(s string) string {
+	secrets.mu.RLock()
+	defer secrets.mu.RUnlock()
+
+	for secret := range secrets.values {
+		s = strings.ReplaceAll(s, secret, redactedValue)
+	}
+
+	return s
+}
+
+// redactFields replaces every registered secret in the string values of structured log fields.
+func redactFields(fields []map[string]interface{}) []map[string]interface{} {
+	redacted := make([]map[string]interface{}, 0, len(fields))
+
+	for _, f := range fields {
+		r := make(map[string]interface{}, len(f))
+		for k, v := range f {
+			if vs, ok := v.(string); ok {
+				v = redact(vs)
+			}
+			r[k] = v
+		}
+		redacted = append(redacted, r)
+	}
+
+	return redacted
+}
+
+// logDebug logs a message at DEBUG with all registered secrets masked.
+func logDebug(ctx context.Context, msg string, fields ...map[string]interface{}) {
+	tflog.Debug(ctx, redact(msg), redactFields(fields)...)
+}
+
+// logInfo logs a message at INFO with all registered secrets masked.
+func logInfo(ctx context.Context, msg string, fields ...map[string]interface{}) {
+	tflog.Info(ctx, redact(msg), redactFields(fields)...)
+}
+
+// logWarn logs a message at WARN with all registered secrets masked.
+func logWarn(ctx context.Context, msg string, fields ...map[string]interface{}) {
+	tflog.Warn(ctx, redact(msg), redactFields(fields)...)
+}
+
+// redactHTTPDump masks credential headers, credential JSON fields and registered secrets in a HTTP dump.
+func redactHTTPDump(dump []byte) string {
+	s := sensitiveHeaderRegex.ReplaceAllString(string(dump), "${1}"+redactedValue)
+	s = sensitiveFieldRegex.ReplaceAllString(s, `${1}"`+redactedValue+`"`)
+	return redact(s)
+}
+
+// requestLogContext returns the context to log a request with.
+//
+// Requests made without a context fall back to the given context, which carries the provider's logger.
+func requestLogContext(req *http.Request, fallback context.Context) context.Context {
+	if req.Context() == context.Background() {
+		return fallback
+	}
+	return req.Context()
+}
+
+// loggingTransport is a http.RoundTripper that logs redacted request and response dumps.
+type loggingTransport struct {
+	transport http.RoundTripper
+	ctx       context.Context
+}
+
+// newLoggingTransport returns a loggingTransport wrapping the given http.RoundTripper.
+//
+// The context is used for logging requests that were made without a context.
+func newLoggingTransport(ctx context.Context, transport http.RoundTripper) *loggingTransport {
+	return &loggingTransport{
+		transport: transport,
+		ctx:       ctx,
+	}
+}
+
+// RoundTrip executes the request and logs both the request and the response at DEBUG.
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !logging.IsDebugOrHigher() {
+		return t.transport.RoundTrip(req)
+	}
+
+	ctx := tflog.NewSubsystem(requestLogContext(req, t.ctx), logSubsystemHTTP)
+
+	fields := map[string]interface{}{
+		"http_method": req.Method,
+		"http_path":   redact(req.URL.Path),
+	}
+
+	if reqDump, dumpErr := httputil.DumpRequestOut(req, true); dumpErr == nil {
+		tflog.SubsystemDebug(ctx, logSubsystemHTTP, "API request: "+redactHTTPDump(reqDump), fields)
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		tflog.SubsystemDebug(ctx, logSubsystemHTTP, "API request failed: "+redact(err.Error()), fields)
+		return resp, err
+	}
+
+	fields["http_status"] = resp.StatusCode
+	if respDump, dumpErr := httputil.DumpResponse(resp, true); dumpErr == nil {
+		tflog.SubsystemDebug(ctx, logSubsystemHTTP, "API response: "+redactHTTPDump(respDump), fields)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,35 @@
+package rollbar
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRedact_RegisteredSecret(t *testing.T) {
+	registerSecret("a1b2c3d4e5f6a7b8")
+
+	assert.Equal(t, "token is ***", redact("token is a1b2c3d4e5f6a7b8"))
+}
+
+func TestRedact_IgnoresShortSecrets(t *testing.T) {
+	registerSecret("abc")
+
+	assert.Equal(t, "abc is not masked", redact("abc is not masked"))
+}
+
+func TestRedactHTTPDump(t *testing.T) {
+	dump := "PATCH /api/1/project/1 HTTP/1.1\r\n" +
+		"Authorization: Bearer foobar\r\n" +
+		"X-Rollbar-Access-Token: 0123456789abcdef\r\n" +
+		"\r\n" +
+		`{"result": [{"name": "read", "access_token": "fedcba9876543210"}], "service_key": "pagerduty"}`
+
+	redacted := redactHTTPDump([]byte(dump))
+
+	assert.Contains(t, redacted, "Authorization: ***")
+	assert.Contains(t, redacted, "X-Rollbar-Access-Token: ***")
+	assert.Contains(t, redacted, `"access_token": "***"`)
+	assert.Contains(t, redacted, `"service_key": "***"`)
+	assert.NotContains(t, redacted, "0123456789abcdef")
+	assert.NotContains(t, redacted, "fedcba9876543210")
+}
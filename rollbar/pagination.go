@@ -0,0 +1,106 @@
+package rollbar
+
+import (
+	"context"
+	"fmt"
+	"github.com/davidji99/rollrest-go/rollrest"
+	"github.com/davidji99/simpleresty"
+	"net/http"
+)
+
+// pageQueryParam is the query parameter used to request a specific page of a list endpoint.
+const pageQueryParam = "page"
+
+// eachPage walks every page of the list endpoint at path, starting with the first page.
+//
+// Each page is decoded into the value returned by newPage and passed to handlePage, which calls add with
+// the key of every item on the page. add reports whether the item has not been seen on a previous page.
+// Iteration stops at the first page without any new item, so endpoints that ignore the page parameter
+// and return the same results for every page are only read once.
+//
+// Only an error on the first page is returned with its response. An error on a later page, including a 404,
+// does not mean the listed object does not exist and is returned as a plain error that isNotFound does not match.
+func (c *Config) eachPage(ctx context.Context, token, path string, newPage func() interface{},
+	handlePage func(page interface{}, add func(key string) bool)) (*simpleresty.Response, error) {
+	seen := make(map[string]bool)
+
+	for pageNumber := 1; ; pageNumber++ {
+		page := newPage()
+
+		response, err := c.request(ctx, token, http.MethodGet,
+			fmt.Sprintf("%s?%s=%d", path, pageQueryParam, pageNumber), nil, page)
+		if err != nil {
+			if pageNumber == 1 {
+				return response, err
+			}
+			return nil, fmt.Errorf("unable to retrieve page %d of %s: %s", pageNumber, path, err.Error())
+		}
+
+		newItems := 0
+		handlePage(page, func(key string) bool {
+			if seen[key] {
+				return false
+			}
+			seen[key] = true
+			newItems++
+			return true
+		})
+
+		if newItems == 0 {
+			return response, nil
+		}
+	}
+}
+
+// listAllUsers retrieves every page of the account's users.
+func (c *Config) listAllUsers(ctx context.Context) ([]*rollrest.User, error) {
+	var users []*rollrest.User
+
+	_, err := c.eachPage(ctx, c.accountAccessToken, "/users", func() interface{} {
+		return &rollrest.UserListResponse{}
+	}, func(page interface{}, add func(key string) bool) {
+		for _, user := range page.(*rollrest.UserListResponse).GetResult().Users {
+			if add(Int64ToString(user.GetID())) {
+				users = append(users, user)
+			}
+		}
+	})
+
+	return users, err
+}
+
+// listAllProjects retrieves every page of the account's projects, including deleted ones.
+func (c *Config) listAllProjects(ctx context.Context) ([]*rollrest.Project, error) {
+	var projects []*rollrest.Project
+
+	_, err := c.eachPage(ctx, c.accountAccessToken, "/projects", func() interface{} {
+		return &rollrest.ProjectListResponse{}
+	}, func(page interface{}, add func(key string) bool) {
+		for _, project := range page.(*rollrest.ProjectListResponse).Result {
+			if add(Int64ToString(project.GetID())) {
+				projects = append(projects, project)
+			}
+		}
+	})
+
+	return projects, err
+}
+
+// listProjectAccessTokens retrieves every page of a project's access tokens.
+func (c *Config) listProjectAccessTokens(ctx context.Context,
+	projectID int) ([]*rollrest.ProjectAccessToken, *simpleresty.Response, error) {
+	var pats []*rollrest.ProjectAccessToken
+
+	response, err := c.eachPage(ctx, c.accountAccessToken, fmt.Sprintf("/project/%d/access_tokens", projectID),
+		func() interface{} {
+			return &rollrest.ProjectAccessTokenListResponse{}
+		}, func(page interface{}, add func(key string) bool) {
+			for _, pat := range page.(*rollrest.ProjectAccessTokenListResponse).Result {
+				if add(pat.GetAccessToken()) {
+					pats = append(pats, pat)
+				}
+			}
+		})
+
+	return pats, response, err
+}
@@ -0,0 +1,90 @@
+package rollbar
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestConfigListAllUsers_MultiplePages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/users", r.URL.Path)
+		assert.Equal(t, "account-token", r.Header.Get("x-rollbar-access-token"))
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("page") {
+		case "1":
+			w.Write([]byte(`{"err": 0, "result": {"users": [{"id": 1, "email": "a@example.com"}, {"id": 2, "email": "b@example.com"}]}}`))
+		case "2":
+			w.Write([]byte(`{"err": 0, "result": {"users": [{"id": 3, "email": "c@example.com"}]}}`))
+		default:
+			w.Write([]byte(`{"err": 0, "result": {"users": []}}`))
+		}
+	}))
+	defer server.Close()
+
+	config := newTestConfig(t, server.URL)
+
+	users, err := config.listAllUsers(context.Background())
+
+	assert.Nil(t, err)
+	if assert.Len(t, users, 3) {
+		assert.Equal(t, "c@example.com", users[2].GetEmail())
+	}
+}
+
+func TestConfigListAllProjects_PageParameterIgnored(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"err": 0, "result": [{"id": 1, "name": "a"}, {"id": 2, "name": "b"}]}`))
+	}))
+	defer server.Close()
+
+	config := newTestConfig(t, server.URL)
+
+	projects, err := config.listAllProjects(context.Background())
+
+	assert.Nil(t, err)
+	assert.Len(t, projects, 2)
+	assert.Equal(t, int32(2), requests)
+}
+
+func TestConfigListProjectAccessTokens_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"err": 0, "result": [{"project_id": 123, "name": "read", "access_token": "read-token"}]}`))
+	}))
+	defer server.Close()
+
+	config := newTestConfig(t, server.URL)
+
+	_, response, err := config.listProjectAccessTokens(context.Background(), 123)
+
+	assert.NotNil(t, err)
+	assert.False(t, isNotFound(response, err))
+}
+
+func TestConfigListProjectAccessTokens_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	config := newTestConfig(t, server.URL)
+
+	_, response, err := config.listProjectAccessTokens(context.Background(), 123)
+
+	assert.NotNil(t, err)
+	assert.True(t, isNotFound(response, err))
+}
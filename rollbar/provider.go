@@ -2,9 +2,10 @@ package rollbar
 
 import (
 	"context"
+	"github.com/davidji99/rollrest-go/rollrest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Provider returns a schema.Provider.
@@ -23,22 +24,90 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("ROLLBAR_ACCOUNT_ACCESS_TOKEN", nil),
 			},
 
+			"project_access_tokens": {
+				Type:      schema.TypeMap,
+				Optional:  true,
+				Sensitive: true,
+				Elem:      &schema.Schema{Type: schema.TypeString},
+			},
+
+			"fetch_project_access_tokens": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"api_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ROLLBAR_API_URL", rollrest.DefaultAPIBaseURL),
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+
 			"headers": {
 				Type:     schema.TypeMap,
 				Elem:     schema.TypeString,
 				Optional: true,
 			},
 
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      DefaultMaxRetries,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"retry_max_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      DefaultRetryMaxWait,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"requests_per_minute": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"skip_credentials_validation": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"disable_lookup_cache": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			"post_create_pd_integration_delete_default_rules": {
 				Type:     schema.TypeBool,
 				Optional: true,
 				Default:  false,
 			},
+
+			"protect_all_projects": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
 			"rollbar_project":               dataSourceRollbarProject(),
+			"rollbar_project_access_token":  dataSourceRollbarProjectAccessToken(),
 			"rollbar_project_access_tokens": dataSourceRollbarProjectAccessTokens(),
+			"rollbar_projects":              dataSourceRollbarProjects(),
 			"rollbar_team":                  dataSourceRollbarTeam(),
 			"rollbar_user":                  dataSourceRollbarUser(),
 		},
@@ -48,6 +117,7 @@ func Provider() *schema.Provider {
 			"rollbar_pagerduty_notification_rule": resourceRollbarPagerDutyNotificationRule(),
 			"rollbar_project":                     resourceRollbarProject(),
 			"rollbar_project_access_token":        resourceRollbarProjectAccessToken(),
+			"rollbar_project_settings":            resourceRollbarProjectSettings(),
 			"rollbar_team":                        resourceRollbarTeam(),
 			"rollbar_team_project_association":    resourceRollbarTeamProjectAssociation(),
 			"rollbar_team_user_association":       resourceRollbarTeamUserAssociation(),
@@ -58,30 +128,40 @@ func Provider() *schema.Provider {
 }
 
 // providerConfigure configures the rollbar api client
-func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	log.Println("[INFO] Initializing Rollbar Provider")
+func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	logInfo(ctx, "Initializing Rollbar Provider")
 
 	config := NewConfig()
 
 	if accountAccessToken, ok := d.GetOk("account_access_token"); ok {
-		log.Printf("[DEBUG] account_access_token to be used: %v", accountAccessToken)
 		config.accountAccessToken = accountAccessToken.(string)
+		registerSecret(config.accountAccessToken)
+		logDebug(ctx, "account_access_token is set")
 	}
 
 	if projectAccessToken, ok := d.GetOk("project_access_token"); ok {
-		log.Printf("[DEBUG] project_access_token to be used: %v", projectAccessToken)
 		config.projectAccessToken = projectAccessToken.(string)
+		registerSecret(config.projectAccessToken)
+		logDebug(ctx, "project_access_token is set")
 	}
 
-	if applySchemaErr := config.applySchema(d); applySchemaErr != nil {
+	if applySchemaErr := config.applySchema(ctx, d); applySchemaErr != nil {
 		return nil, diag.FromErr(applySchemaErr)
 	}
 
-	if err := config.initializeAPI(); err != nil {
+	if err := config.initializeAPI(ctx); err != nil {
 		return nil, diag.FromErr(err)
 	}
 
-	log.Printf("[DEBUG] Rollbar provider initialized")
+	var diags diag.Diagnostics
+	if !config.SkipCredentialsValidation {
+		diags = config.validateCredentials(ctx)
+		if diags.HasError() {
+			return nil, diags
+		}
+	}
+
+	logDebug(ctx, "Rollbar provider initialized")
 
-	return config, nil
+	return config, diags
 }
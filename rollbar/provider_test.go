@@ -1,8 +1,12 @@
 package rollbar
 
 import (
+	"context"
 	helper "github.com/davidji99/terraform-provider-rollbar/helper/test"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -28,6 +32,31 @@ func TestProvider_impl(t *testing.T) {
 	var _ *schema.Provider = Provider()
 }
 
+func TestProviderConfigure_APIURL(t *testing.T) {
+	var requestedPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"err": 0, "result": []}`))
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"account_access_token": "foobar",
+		"api_url":              server.URL + "/api/1/",
+	})
+
+	meta, diags := providerConfigure(context.Background(), d)
+	assert.False(t, diags.HasError())
+
+	config := meta.(*Config)
+	assert.Equal(t, server.URL+"/api/1", config.BaseURL)
+
+	_, _, listErr := config.API.Projects.List()
+	assert.Nil(t, listErr)
+	assert.Equal(t, "/api/1/projects", requestedPath)
+}
+
 func testAccPreCheck(t *testing.T) {
 	testAccConfig.GetOrAbort(t, helper.TestConfigAccountAccessToken)
 }
@@ -1,26 +1,41 @@
 package rollbar
 
 import (
+	"context"
 	"fmt"
 	"github.com/davidji99/rollrest-go/rollrest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	"log"
 	"strconv"
 	"time"
 )
 
 func resourceRollbarPagerDutyIntegration() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceRollbarPagerDutyIntegrationCreate,
-		Read:   resourceRollbarPagerDutyIntegrationRead,
-		Delete: resourceRollbarPagerDutyIntegrationDelete,
+		CreateContext: resourceRollbarPagerDutyIntegrationCreate,
+		ReadContext:   resourceRollbarPagerDutyIntegrationRead,
+		DeleteContext: resourceRollbarPagerDutyIntegrationDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: resourceRollbarPagerDutyIntegrationImport,
+			StateContext: resourceRollbarPagerDutyIntegrationImport,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		CustomizeDiff: requireProjectAccessToken("rollbar_pagerduty_integration"),
+
 		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+
 			"service_key": {
 				Type:         schema.TypeString,
 				Required:     true,
@@ -38,51 +53,54 @@ func resourceRollbarPagerDutyIntegration() *schema.Resource {
 	}
 }
 
-func resourceRollbarPagerDutyIntegrationImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourceRollbarPagerDutyIntegrationImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	return nil, fmt.Errorf("not possible to import PagerDuty integration due to API limitations")
 }
 
-func resourceRollbarPagerDutyIntegrationCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*Config).API
+func resourceRollbarPagerDutyIntegrationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := meta.(*Config).projectClient(ctx, getProjectID(ctx, d))
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	opts := &rollrest.PDIntegrationRequest{}
 
 	if v, ok := d.GetOk("service_key"); ok {
 		vs := v.(string)
-		log.Printf("[DEBUG] service_key is : %s", vs)
+		registerSecret(vs)
 		opts.ServiceKey = vs
 	}
 
 	vs := d.Get("enabled").(bool)
-	log.Printf("[DEBUG] enabled is : %v", vs)
+	logDebug(ctx, "PagerDuty integration enabled", map[string]interface{}{"enabled": vs})
 	opts.Enabled = vs
 
-	log.Printf("[DEBUG] Adding PagerDuty integration %v", opts)
+	logDebug(ctx, "Adding PagerDuty integration")
 
 	_, createErr := client.Notifications.ConfigurePagerDutyIntegration(opts)
 	if createErr != nil {
-		return createErr
+		return diag.FromErr(createErr)
 	}
 
-	log.Printf("[DEBUG] Added PagerDuty integration %v", opts)
+	logDebug(ctx, "Added PagerDuty integration")
 
 	if meta.(*Config).PostCreatePDIntegrationDeleteDefaultRules {
-		log.Printf("[DEBUG] Deleting default rules added on service_key %s", opts.ServiceKey)
+		logDebug(ctx, "Deleting default rules added by the PagerDuty integration")
 
 		_, _, deleteErr := client.Notifications.DeleteAllPagerDutyRules()
 		if deleteErr != nil {
-			return deleteErr
+			return diag.FromErr(deleteErr)
 		}
 
-		log.Printf("[DEBUG] Deleted default rules added on service_key %s", opts.ServiceKey)
+		logDebug(ctx, "Deleted default rules added by the PagerDuty integration")
 	}
 
 	// Set the resource ID to be the epoch time in nanoseconds
 	d.SetId(strconv.Itoa(time.Now().Nanosecond()))
 
-	return resourceRollbarPagerDutyIntegrationRead(d, meta)
+	return resourceRollbarPagerDutyIntegrationRead(ctx, d, meta)
 }
 
-func resourceRollbarPagerDutyIntegrationRead(d *schema.ResourceData, meta interface{}) error {
+func resourceRollbarPagerDutyIntegrationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// There is no GET API endpoint so state will set whatever value is defined in the user's configuration.
 	d.Set("service_key", d.Get("service_key"))
 	d.Set("enabled", d.Get("enabled"))
@@ -90,23 +108,26 @@ func resourceRollbarPagerDutyIntegrationRead(d *schema.ResourceData, meta interf
 	return nil
 }
 
-func resourceRollbarPagerDutyIntegrationDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceRollbarPagerDutyIntegrationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// There is no DELETE API endpoint so resource deletion will entail disabling the integration.
 	// Users will need to visit the UI to manually remove the integration.
-	client := meta.(*Config).API
+	client, clientErr := meta.(*Config).projectClient(ctx, getProjectID(ctx, d))
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	opts := &rollrest.PDIntegrationRequest{}
 
 	opts.ServiceKey = d.Get("service_key").(string)
 	opts.Enabled = false
 
-	log.Printf("[DEBUG] Disabling PagerDuty integration %v", opts)
+	logDebug(ctx, "Disabling PagerDuty integration")
 
 	_, createErr := client.Notifications.ConfigurePagerDutyIntegration(opts)
 	if createErr != nil {
-		return createErr
+		return diag.FromErr(createErr)
 	}
 
-	log.Printf("[DEBUG] Disabled PagerDuty integration %v", opts)
+	logDebug(ctx, "Disabled PagerDuty integration")
 
 	d.SetId("")
 
@@ -1,11 +1,12 @@
 package rollbar
 
 import (
+	"context"
 	"fmt"
 	"github.com/davidji99/rollrest-go/rollrest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	"log"
 	"strconv"
 	"time"
 )
@@ -23,16 +24,31 @@ var (
 
 func resourceRollbarPagerDutyNotificationRule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceRollbarPagerDutyNotificationRuleCreate,
-		Read:   resourceRollbarPagerDutyNotificationRuleRead,
-		Update: resourceRollbarPagerDutyNotificationRuleUpdate,
-		Delete: resourceRollbarPagerDutyNotificationRuleDelete,
+		CreateContext: resourceRollbarPagerDutyNotificationRuleCreate,
+		ReadContext:   resourceRollbarPagerDutyNotificationRuleRead,
+		UpdateContext: resourceRollbarPagerDutyNotificationRuleUpdate,
+		DeleteContext: resourceRollbarPagerDutyNotificationRuleDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: resourceRollbarPagerDutyNotificationRuleImport,
+			StateContext: resourceRollbarPagerDutyNotificationRuleImport,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		CustomizeDiff: requireProjectAccessToken("rollbar_pagerduty_notification_rule"),
+
 		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+
 			"rule": {
 				ConfigMode: schema.SchemaConfigModeBlock,
 				Type:       schema.TypeList,
@@ -104,55 +120,60 @@ func resourceRollbarPagerDutyNotificationRule() *schema.Resource {
 	}
 }
 
-func resourceRollbarPagerDutyNotificationRuleImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourceRollbarPagerDutyNotificationRuleImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	return nil, fmt.Errorf("not possible to import PagerDuty notification rule(s) due to API limitations")
 }
 
-func resourceRollbarPagerDutyNotificationRuleCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceRollbarPagerDutyNotificationRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// The Create function will call the Update function since the API does not have a Post, only Put.
-	updateErr := resourceRollbarPagerDutyNotificationRuleUpdate(d, meta)
-	if updateErr != nil {
-		return updateErr
+	if updateDiags := resourceRollbarPagerDutyNotificationRuleUpdate(ctx, d, meta); updateDiags.HasError() {
+		return updateDiags
 	}
 
 	// Set the resource ID to be the epoch time in nanoseconds
 	d.SetId(strconv.Itoa(time.Now().Nanosecond()))
 
-	return resourceRollbarPagerDutyNotificationRuleUpdate(d, meta)
+	return resourceRollbarPagerDutyNotificationRuleUpdate(ctx, d, meta)
 }
 
-func resourceRollbarPagerDutyNotificationRuleRead(d *schema.ResourceData, meta interface{}) error {
+func resourceRollbarPagerDutyNotificationRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Set state to what is defined in schema as there is no READ endpoint.
-	return d.Set("rule", d.Get("rule"))
+	return diag.FromErr(d.Set("rule", d.Get("rule")))
 }
 
-func resourceRollbarPagerDutyNotificationRuleUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*Config).API
+func resourceRollbarPagerDutyNotificationRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := meta.(*Config).projectClient(ctx, getProjectID(ctx, d))
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	opts := constructRuleDefinitions(d)
 
-	log.Printf("[DEBUG] Modifying PagerDuty notification rule(s) %v", opts)
+	logDebug(ctx, "Modifying PagerDuty notification rules", map[string]interface{}{"rule_count": len(opts)})
 
 	isModified, _, modifyErr := client.Notifications.ModifyPagerDutyRules(opts)
 	if modifyErr != nil {
-		return modifyErr
+		return diag.FromErr(modifyErr)
 	}
 
-	log.Printf("[DEBUG] Was modifying PagerDuty notification rule(s) successful: %v", isModified)
+	logDebug(ctx, "Modified PagerDuty notification rules", map[string]interface{}{"modified": isModified})
 
-	return resourceRollbarPagerDutyNotificationRuleRead(d, meta)
+	return resourceRollbarPagerDutyNotificationRuleRead(ctx, d, meta)
 }
 
-func resourceRollbarPagerDutyNotificationRuleDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*Config).API
+func resourceRollbarPagerDutyNotificationRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := meta.(*Config).projectClient(ctx, getProjectID(ctx, d))
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
-	log.Printf("[DEBUG] Deleting all PagerDuty notification rules")
+	logDebug(ctx, "Deleting all PagerDuty notification rules")
 
 	isDeleted, _, deleteErr := client.Notifications.DeleteAllPagerDutyRules()
 	if deleteErr != nil {
-		return deleteErr
+		return diag.FromErr(deleteErr)
 	}
 
-	log.Printf("[DEBUG] Was all PagerDuty notification rules deleted: %v", isDeleted)
+	logDebug(ctx, "Deleted all PagerDuty notification rules", map[string]interface{}{"deleted": isDeleted})
 
 	d.SetId("")
 
@@ -184,6 +205,7 @@ func constructRuleDefinitions(d *schema.ResourceData) []*rollrest.PDRuleRequest
 
 				if serviceKeyRaw, ok := config.(map[string]interface{})["service_key"]; ok {
 					configOpt.ServiceKey = serviceKeyRaw.(string)
+					registerSecret(configOpt.ServiceKey)
 				}
 
 				pdRule.Config = configOpt
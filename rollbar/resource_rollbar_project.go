@@ -1,33 +1,102 @@
 package rollbar
 
 import (
+	"context"
+	"fmt"
 	"github.com/davidji99/rollrest-go/rollrest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	"log"
 	"regexp"
+	"strings"
+	"time"
 )
 
+// defaultProjectAccessTokens are the names of the access tokens Rollbar creates with every new project.
+// Each token is named after its only scope.
+var defaultProjectAccessTokens = []string{"read", "write", "post_server_item", "post_client_item"}
+
 func resourceRollbarProject() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceRollbarProjectCreate,
-		Read:   resourceRollbarProjectRead,
-		Delete: resourceRollbarProjectDelete,
+		CreateContext: resourceRollbarProjectCreate,
+		ReadContext:   resourceRollbarProjectRead,
+		UpdateContext: resourceRollbarProjectUpdate,
+		DeleteContext: resourceRollbarProjectDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: resourceRollbarProjectImport,
+			StateContext: resourceRollbarProjectImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
 
+		CustomizeDiff: customdiff.Sequence(
+			requireAccountAccessToken("rollbar_project"),
+			planProjectAdoption,
+		),
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
 				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[a-zA-Z][0-9A-Za-z,.\-_]{1,31}$`),
 					"Must start with a letter and can only contain letters, numbers, underscores, "+
 						"hyphens, and periods. Max length 32 characters."),
 			},
 
+			"deletion_protection": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"adopt_existing": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"adopted": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"default_tokens": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "keep",
+				ValidateFunc: validation.StringInSlice([]string{"keep", "disable", "rate_limit"}, false),
+			},
+
+			"read_access_token": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"write_access_token": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"post_server_item_access_token": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"post_client_item_access_token": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
 			"status": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -41,64 +110,291 @@ func resourceRollbarProject() *schema.Resource {
 	}
 }
 
-func resourceRollbarProjectImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourceRollbarProjectImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	d.SetId(d.Id())
-	readErr := resourceRollbarProjectRead(d, meta)
+	d.Set("deletion_protection", true)
+	d.Set("adopt_existing", false)
+	d.Set("adopted", false)
+	d.Set("default_tokens", "keep")
 
-	return []*schema.ResourceData{d}, readErr
+	if readDiags := resourceRollbarProjectRead(ctx, d, meta); readDiags.HasError() {
+		return nil, fmt.Errorf("unable to import project: %s", readDiags[0].Summary)
+	}
+
+	return []*schema.ResourceData{d}, nil
 }
 
-func resourceRollbarProjectCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*Config).API
+func resourceRollbarProjectCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := meta.(*Config).apiClient(ctx)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	opts := &rollrest.ProjectRequest{}
 
 	if v, ok := d.GetOk("name"); ok {
 		vs := v.(string)
-		log.Printf("[DEBUG] project name is : %s", vs)
+		logDebug(ctx, "project name", map[string]interface{}{"name": vs})
 		opts.Name = vs
 	}
 
-	log.Printf("[DEBUG] Creating new project %s", opts.Name)
+	if d.Get("adopt_existing").(bool) {
+		existing, findErr := findProjectByName(ctx, meta.(*Config), opts.Name)
+		if findErr != nil {
+			return diag.FromErr(findErr)
+		}
+
+		if existing != nil {
+			logDebug(ctx, "Adopting existing project", map[string]interface{}{"name": opts.Name})
+
+			d.SetId(Int64ToString(existing.GetID()))
+			d.Set("adopted", true)
+
+			diags := resourceRollbarProjectRead(ctx, d, meta)
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Adopted existing project %s", opts.Name),
+				Detail: fmt.Sprintf("A project named %s already exists, so project %s was added to state "+
+					"instead of creating a new project.", opts.Name, d.Id()),
+			})
+		}
+	}
+
+	logDebug(ctx, "Creating new project", map[string]interface{}{"name": opts.Name})
 
 	newProject, _, createErr := client.Projects.Create(opts)
 	if createErr != nil {
-		return createErr
+		return diag.FromErr(createErr)
 	}
 
-	log.Printf("[DEBUG] Created new project %s", opts.Name)
+	logDebug(ctx, "Created new project", map[string]interface{}{"name": opts.Name})
+	meta.(*Config).cache.projects.invalidate()
 
 	d.SetId(Int64ToString(newProject.GetResult().GetID()))
+	d.Set("adopted", false)
+
+	if mode := d.Get("default_tokens").(string); mode != "keep" {
+		neutralizeErr := neutralizeDefaultProjectAccessTokens(ctx, meta.(*Config), int(newProject.GetResult().GetID()), mode)
+		if neutralizeErr != nil {
+			return diag.FromErr(neutralizeErr)
+		}
+	}
 
-	return resourceRollbarProjectRead(d, meta)
+	return resourceRollbarProjectRead(ctx, d, meta)
 }
 
-func resourceRollbarProjectRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*Config).API
+func resourceRollbarProjectRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := meta.(*Config).apiClient(ctx)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+
+	project, response, getErr := client.Projects.Get(StringToInt(d.Id()))
+
+	// Deleted projects are still returned by the API but without a name.
+	if getErr == nil && project.GetResult().GetName() == "" {
+		getErr = NotFoundError{fmt.Errorf("project %s has been deleted", d.Id())}
+	}
+
+	if removeFromStateIfNotFound(ctx, d, "rollbar_project", response, getErr) {
+		return nil
+	}
 
-	project, _, getErr := client.Projects.Get(StringToInt(d.Id()))
 	if getErr != nil {
-		return getErr
+		return diag.FromErr(getErr)
 	}
 
 	d.Set("name", project.GetResult().GetName())
 	d.Set("status", project.GetResult().GetStatus())
 	d.Set("account_id", project.GetResult().GetAccountID())
 
+	readDefaultProjectAccessTokens(ctx, d, meta.(*Config))
+
 	return nil
 }
 
-func resourceRollbarProjectDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*Config).API
+// readDefaultProjectAccessTokens sets the default access tokens of a project.
+//
+// The value of a token never changes, so the tokens are only listed until all of them are known.
+// The default access tokens are optional attributes of a project, so failing to list them only logs a warning.
+func readDefaultProjectAccessTokens(ctx context.Context, d *schema.ResourceData, config *Config) {
+	// Neutralized default tokens are not exported.
+	if d.Get("default_tokens").(string) != "keep" {
+		for _, name := range defaultProjectAccessTokens {
+			d.Set(name+"_access_token", "")
+		}
+		return
+	}
+
+	known := true
+	for _, name := range defaultProjectAccessTokens {
+		if v := d.Get(name + "_access_token").(string); v != "" {
+			registerSecret(v)
+		} else {
+			known = false
+		}
+	}
+
+	if known {
+		return
+	}
+
+	pats, _, listErr := config.listProjectAccessTokens(ctx, StringToInt(d.Id()))
+	if listErr != nil {
+		logWarn(ctx, "Unable to read the default access tokens of project",
+			map[string]interface{}{"project_id": d.Id(), "error": listErr.Error()})
+		return
+	}
+
+	for _, name := range defaultProjectAccessTokens {
+		var accessToken string
+		if pat := defaultProjectAccessToken(pats, name); pat != nil {
+			accessToken = pat.GetAccessToken()
+			registerSecret(accessToken)
+		}
+		d.Set(name+"_access_token", accessToken)
+	}
+}
+
+// defaultProjectAccessToken returns the default access token with the given name or nil if there is none.
+//
+// Rollbar creates each default token enabled and with a single scope named after the token. Tokens that were
+// disabled, neutralized or had their scopes changed are skipped. If several tokens remain, for example because
+// a token with the same name was created later, the oldest one is the default token.
+func defaultProjectAccessToken(pats []*rollrest.ProjectAccessToken, name string) *rollrest.ProjectAccessToken {
+	var oldest *rollrest.ProjectAccessToken
+
+	for _, pat := range pats {
+		if pat.GetName() != name || pat.GetStatus() != "enabled" || isNeutralizedProjectAccessToken(pat) ||
+			len(pat.Scopes) != 1 || pat.Scopes[0] != name {
+			continue
+		}
+
+		if oldest == nil || pat.GetDataCreated() < oldest.GetDataCreated() {
+			oldest = pat
+		}
+	}
+
+	return oldest
+}
+
+func resourceRollbarProjectUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	if d.HasChange("name") {
+		opts := &projectUpdateRequest{Name: d.Get("name").(string)}
+
+		logDebug(ctx, "Renaming project", map[string]interface{}{"project_id": d.Id(), "name": opts.Name})
+
+		_, _, updateErr := config.updateProject(ctx, StringToInt(d.Id()), opts)
+		if updateErr != nil {
+			return diag.FromErr(updateErr)
+		}
+
+		logDebug(ctx, "Renamed project", map[string]interface{}{"project_id": d.Id(), "name": opts.Name})
+		config.cache.projects.invalidate()
+	}
+
+	return resourceRollbarProjectRead(ctx, d, meta)
+}
+
+func resourceRollbarProjectDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := meta.(*Config).apiClient(ctx)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+
+	if d.Get("deletion_protection").(bool) || meta.(*Config).ProtectAllProjects {
+		return diag.Diagnostics{deletionProtectionDiagnostic("project", d.Id(), meta.(*Config).ProtectAllProjects)}
+	}
 
-	log.Printf("[DEBUG] Project id to be deleted: %v", d.Id())
+	logDebug(ctx, "Deleting project", map[string]interface{}{"project_id": d.Id()})
 
 	_, deleteErr := client.Projects.Delete(StringToInt(d.Id()))
 	if deleteErr != nil {
-		return deleteErr
+		return diag.FromErr(deleteErr)
 	}
 
-	log.Printf("[DEBUG] Deleted Project id : %v", d.Id())
+	logDebug(ctx, "Deleted project", map[string]interface{}{"project_id": d.Id()})
+	meta.(*Config).cache.projects.invalidate()
 	d.SetId("")
 
 	return nil
 }
+
+// planProjectAdoption marks in the plan whether a new project with adopt_existing enabled adopts an existing project.
+func planProjectAdoption(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" {
+		return nil
+	}
+
+	if !d.NewValueKnown("adopt_existing") || !d.NewValueKnown("name") {
+		return d.SetNewComputed("adopted")
+	}
+
+	if !d.Get("adopt_existing").(bool) {
+		return d.SetNew("adopted", false)
+	}
+
+	existing, findErr := findProjectByName(ctx, meta.(*Config), d.Get("name").(string))
+	if findErr != nil {
+		return findErr
+	}
+
+	if existing != nil {
+		return d.SetNew("adopted", true)
+	}
+
+	// The project may still be created by someone else before the apply.
+	return d.SetNewComputed("adopted")
+}
+
+// findProjectByName returns the non-deleted project with the given name or nil if there is none.
+func findProjectByName(ctx context.Context, config *Config, name string) (*rollrest.Project, error) {
+	projects, listErr := config.listProjects(ctx)
+	if listErr != nil {
+		return nil, listErr
+	}
+
+	for _, project := range projects {
+		if project.GetName() == name {
+			return project, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// neutralizeDefaultProjectAccessTokens disables or rate limits the access tokens Rollbar creates with a new project.
+func neutralizeDefaultProjectAccessTokens(ctx context.Context, config *Config, projectID int, mode string) error {
+	pats, _, listErr := config.listProjectAccessTokens(ctx, projectID)
+	if listErr != nil {
+		return listErr
+	}
+
+	opts := &projectAccessTokenUpdateRequest{Status: "disabled"}
+	if mode == "rate_limit" {
+		opts = &projectAccessTokenUpdateRequest{
+			RateLimitWindowCount: neutralizedRateLimitWindowCount,
+			RateLimitWindowSize:  neutralizedRateLimitWindowSize,
+		}
+	}
+
+	for _, pat := range pats {
+		if DoesNotContain(defaultProjectAccessTokens, pat.GetName()) {
+			continue
+		}
+
+		registerSecret(pat.GetAccessToken())
+
+		_, _, updateErr := config.updateProjectAccessToken(ctx, projectID, pat.GetAccessToken(), opts)
+		if updateErr != nil {
+			return fmt.Errorf("unable to %s default access token %s of project %d: %s",
+				strings.Replace(mode, "_", " ", 1), pat.GetName(), projectID, updateErr)
+		}
+
+		logDebug(ctx, "Applied default_tokens to default access token",
+			map[string]interface{}{"default_tokens": mode, "name": pat.GetName(), "project_id": projectID})
+	}
+
+	return nil
+}
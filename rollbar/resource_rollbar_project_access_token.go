@@ -1,24 +1,67 @@
 package rollbar
 
 import (
+	"context"
 	"fmt"
 	"github.com/davidji99/rollrest-go/rollrest"
+	"github.com/davidji99/simpleresty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	"log"
+	"time"
+)
+
+const (
+	// neutralizedRateLimitWindowCount and neutralizedRateLimitWindowSize limit an access token to one call
+	// every 30 days, which is the closest to invalidating a token with rate limits as a rate limit of 0 calls
+	// is not possible.
+	neutralizedRateLimitWindowCount = 1
+	neutralizedRateLimitWindowSize  = 2592000
+
+	// projectAccessTokenOnDestroyRateLimit, projectAccessTokenOnDestroyDisable and projectAccessTokenOnDestroyAbandon
+	// are the values of on_destroy, which determines what happens to a project access token when it is destroyed.
+	projectAccessTokenOnDestroyRateLimit = "rate_limit"
+	projectAccessTokenOnDestroyDisable   = "disable"
+	projectAccessTokenOnDestroyAbandon   = "abandon"
+
+	// projectAccessTokenDeleteTimeout is the default delete timeout, which bounds the rotation grace period.
+	projectAccessTokenDeleteTimeout = 5 * time.Minute
 )
 
 func resourceRollbarProjectAccessToken() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceRollbarProjectAccessTokenCreate,
-		Read:   resourceRollbarProjectAccessTokenRead,
-		Update: resourceRollbarProjectAccessTokenUpdate,
-		Delete: resourceRollbarProjectAccessTokenDelete,
+		CreateContext: resourceRollbarProjectAccessTokenCreate,
+		ReadContext:   resourceRollbarProjectAccessTokenRead,
+		UpdateContext: resourceRollbarProjectAccessTokenUpdate,
+		DeleteContext: resourceRollbarProjectAccessTokenDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: resourceRollbarProjectAccessTokenImport,
+			StateContext: resourceRollbarProjectAccessTokenImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(projectAccessTokenDeleteTimeout),
 		},
 
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceRollbarProjectAccessTokenV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceRollbarProjectAccessTokenStateUpgradeV0,
+				Version: 0,
+			},
+		},
+
+		CustomizeDiff: customdiff.Sequence(
+			requireAccountAccessToken("rollbar_project_access_token"),
+			validateProjectAccessTokenDiff,
+			planProjectAccessTokenRotation,
+		),
+
 		Schema: map[string]*schema.Schema{
 			"project_id": {
 				Type:     schema.TypeInt,
@@ -46,10 +89,17 @@ func resourceRollbarProjectAccessToken() *schema.Resource {
 			"status": {
 				Type:         schema.TypeString,
 				Required:     true,
-				ForceNew:     true,
 				ValidateFunc: validation.StringInSlice([]string{"enabled", "disabled"}, false),
 			},
 
+			"on_destroy": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  projectAccessTokenOnDestroyRateLimit,
+				ValidateFunc: validation.StringInSlice([]string{projectAccessTokenOnDestroyRateLimit,
+					projectAccessTokenOnDestroyDisable, projectAccessTokenOnDestroyAbandon}, false),
+			},
+
 			"rate_limit_window_size": {
 				Type:         schema.TypeInt,
 				Optional:     true,
@@ -64,6 +114,37 @@ func resourceRollbarProjectAccessToken() *schema.Resource {
 				ValidateFunc: validation.IntAtLeast(1),
 			},
 
+			"rotation": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"rotate_after_days": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+
+						"keepers": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							ForceNew: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+
+						"grace_period": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "0s",
+							ValidateFunc: validateDuration,
+						},
+					},
+				},
+			},
+
 			"cur_rate_limit_window_count": {
 				Type:     schema.TypeInt,
 				Computed: true,
@@ -83,32 +164,113 @@ func resourceRollbarProjectAccessToken() *schema.Resource {
 	}
 }
 
-func resourceRollbarProjectAccessTokenImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	// To import this resource, users must pass in the project ID & access token as the 'ID'.
-	// We then proceed to set one half of the import ID as the "access_token"
-	// before generating a random string number to set as the real resource ID in state.
-	projectID, accessToken, parseErr := ParseCompositeImportID(d.Id())
+func resourceRollbarProjectAccessTokenImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	// To import this resource, users pass in the project ID & token name as the 'ID'
+	// so the access token itself never has to be provided on the command line.
+	parts, parseErr := ParseCompositeID(d.Id(), 2)
 	if parseErr != nil {
 		return nil, parseErr
 	}
 
-	d.Set("project_id", StringToInt(projectID))
-	d.Set("access_token", accessToken)
+	projectID := StringToInt(parts[0])
+	if projectID == 0 {
+		return nil, fmt.Errorf("expected a project ID as the first part of the import ID, got %s", parts[0])
+	}
+
+	pat, findErr := findProjectAccessTokenForImport(ctx, meta.(*Config), projectID, parts[1])
+	if findErr != nil {
+		return nil, findErr
+	}
+
+	registerSecret(pat.GetAccessToken())
+
+	d.Set("project_id", projectID)
+	d.Set("access_token", pat.GetAccessToken())
+	d.Set("on_destroy", projectAccessTokenOnDestroyRateLimit)
+
+	d.SetId(projectAccessTokenID(projectID, pat.GetName()))
+
+	if readDiags := resourceRollbarProjectAccessTokenRead(ctx, d, meta); readDiags.HasError() {
+		return nil, fmt.Errorf("unable to import project access token: %s", readDiags[0].Summary)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// findProjectAccessTokenForImport retrieves the project access token to import by its name.
+//
+// If several tokens have the name, the token is chosen with newestProjectAccessToken.
+// For backwards compatibility, the token is looked up by its value if no token has the name.
+func findProjectAccessTokenForImport(ctx context.Context, config *Config, projectID int,
+	nameOrAccessToken string) (*rollrest.ProjectAccessToken, error) {
+	pats, _, listErr := config.listProjectAccessTokens(ctx, projectID)
+	if listErr != nil {
+		return nil, listErr
+	}
 
-	d.SetId(GenerateRandomResourceID())
+	if pat := newestProjectAccessToken(filterProjectAccessTokens(pats, nameOrAccessToken, "", "")); pat != nil {
+		return pat, nil
+	}
 
-	readErr := resourceRollbarProjectAccessTokenRead(d, meta)
+	for _, pat := range pats {
+		if pat.GetAccessToken() == nameOrAccessToken {
+			return pat, nil
+		}
+	}
 
-	return []*schema.ResourceData{d}, readErr
+	return nil, fmt.Errorf("no project access token named %s found in project %d", nameOrAccessToken, projectID)
 }
 
-func resourceRollbarProjectAccessTokenCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*Config).API
+// newestProjectAccessToken returns the most recently created enabled token, or the most recently created token
+// if none of them is enabled. nil is returned if there are no tokens.
+//
+// Rollbar allows several tokens with the same name, for example after a token was rotated, as tokens cannot be
+// deleted. This rule decides which of them is meant by a name.
+func newestProjectAccessToken(pats []*rollrest.ProjectAccessToken) *rollrest.ProjectAccessToken {
+	var newest *rollrest.ProjectAccessToken
+
+	for _, pat := range pats {
+		switch {
+		case newest == nil:
+			newest = pat
+		case (pat.GetStatus() == "enabled") != (newest.GetStatus() == "enabled"):
+			if pat.GetStatus() == "enabled" {
+				newest = pat
+			}
+		case pat.GetDataCreated() > newest.GetDataCreated():
+			newest = pat
+		}
+	}
+
+	return newest
+}
+
+// isNeutralizedProjectAccessToken returns true if the token's rate limits were set to neutralize it.
+func isNeutralizedProjectAccessToken(pat *rollrest.ProjectAccessToken) bool {
+	return pat.GetRateLimitWindowCount() == neutralizedRateLimitWindowCount &&
+		pat.GetRateLimitWindowSize() == neutralizedRateLimitWindowSize
+}
+
+// projectAccessTokenID returns the resource ID of a project access token.
+//
+// Token names cannot be changed, so the ID is derived from the project ID and token name instead of the token's
+// value. If several tokens have the same name, the ID refers to the token chosen by newestProjectAccessToken,
+// which is the token that importing the ID resolves to.
+func projectAccessTokenID(projectID int, name string) string {
+	return fmt.Sprintf("%d:%s", projectID, name)
+}
+
+func resourceRollbarProjectAccessTokenCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := meta.(*Config).apiClient(ctx)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+
 	opts := &rollrest.PATCreateRequest{}
 
 	if v, ok := d.GetOk("name"); ok {
 		vs := v.(string)
-		log.Printf("[DEBUG] project access token name : %s", vs)
+		logDebug(ctx, "project access token name", map[string]interface{}{"name": vs})
 		opts.Name = vs
 	}
 
@@ -124,50 +286,98 @@ func resourceRollbarProjectAccessTokenCreate(d *schema.ResourceData, meta interf
 
 	if v, ok := d.GetOk("status"); ok {
 		vs := v.(string)
-		log.Printf("[DEBUG] project access token status : %s", vs)
+		logDebug(ctx, "project access token status", map[string]interface{}{"status": vs})
 		opts.Status = vs
 	}
 
 	if v, ok := d.GetOk("rate_limit_window_size"); ok {
 		vs := v.(int)
-
-		// On creation for a new project access token, the API only accepts certain
-		// values for rate_limit_window_size. Therefore, we will validate the user value here.
-		if valErr := validateWinSizeOnCreation(vs); valErr != nil {
-			return valErr
-		}
-
-		log.Printf("[DEBUG] project access token rate_limit_window_size : %d", vs)
+		logDebug(ctx, "project access token rate_limit_window_size", map[string]interface{}{"rate_limit_window_size": vs})
 		opts.RateLimitWindowSize = vs
 	}
 
 	if v, ok := d.GetOk("rate_limit_window_count"); ok {
 		vs := v.(int)
-		log.Printf("[DEBUG] project access token RateLimitWindowCount : %d", vs)
+		logDebug(ctx, "project access token rate_limit_window_count", map[string]interface{}{"rate_limit_window_count": vs})
 		opts.RateLimitWindowCount = vs
 	}
 
-	log.Printf("Creating project access token %s", opts.Name)
+	logDebug(ctx, "Creating project access token", map[string]interface{}{"name": opts.Name})
 
-	newPAT, _, createErr := client.ProjectAccessTokens.Create(getProjectID(d), opts)
+	newPAT, _, createErr := client.ProjectAccessTokens.Create(getProjectID(ctx, d), opts)
 	if createErr != nil {
-		return createErr
+		return diag.FromErr(createErr)
 	}
 
-	log.Printf("Created project access token %s", opts.Name)
+	logDebug(ctx, "Created project access token", map[string]interface{}{"name": opts.Name})
 
-	// Set the ID of the resource to a random number as there's no unique ID for this resource remotely
-	// and we don't want to use the access token as a state resource ID.
-	d.SetId(GenerateRandomResourceID())
+	// There's no unique ID for this resource remotely and we don't want to use the access token
+	// as a state resource ID, so the ID is derived from the project and token name.
+	d.SetId(projectAccessTokenID(getProjectID(ctx, d), opts.Name))
 
 	// Set the access token value so we can use the value in the READ function.
+	registerSecret(newPAT.GetResult().GetAccessToken())
 	d.Set("access_token", newPAT.GetResult().GetAccessToken())
 
-	return resourceRollbarProjectAccessTokenRead(d, meta)
+	return resourceRollbarProjectAccessTokenRead(ctx, d, meta)
+}
+
+// supportedWinSizeOnCreate are the only values for rate_limit_window_size accepted by the API
+// when creating a project access token.
+var supportedWinSizeOnCreate = []int{0, 60, 300, 1800, 3600, 86400, 604800, 2592000}
+
+// validateProjectAccessTokenDiff validates the arguments of a project access token that depend on each other
+// or on whether the token is created so invalid configurations fail during plan instead of apply.
+func validateProjectAccessTokenDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if isConfigured(d, "rate_limit_window_count") && !isConfigured(d, "rate_limit_window_size") {
+		return fmt.Errorf("rate_limit_window_count requires rate_limit_window_size to be set")
+	}
+
+	if v, ok := d.GetOk("rotation"); ok && d.NewValueKnown("rotation") {
+		rotation, _ := v.([]interface{})[0].(map[string]interface{})
+		if rotation == nil || (rotation["rotate_after_days"].(int) == 0 && len(rotation["keepers"].(map[string]interface{})) == 0) {
+			return fmt.Errorf("the rotation block requires rotate_after_days or keepers to be set")
+		}
+	}
+
+	// The grace period is waited for while the replaced token is deleted, so it must end before the delete times out.
+	// Otherwise the apply fails after the replacement token was already created.
+	if v, ok := d.GetOk("rotation.0.grace_period"); ok && d.NewValueKnown("rotation") {
+		gracePeriod, _ := time.ParseDuration(v.(string))
+		if deleteTimeout := configuredDeleteTimeout(d, projectAccessTokenDeleteTimeout); gracePeriod >= deleteTimeout {
+			return fmt.Errorf("the rotation grace period of %s must be shorter than the delete timeout of %s. "+
+				"Please increase the delete timeout in the timeouts block of the resource", gracePeriod, deleteTimeout)
+		}
+	}
+
+	// On creation for a new project access token, the API only accepts certain
+	// values for rate_limit_window_size. Therefore, we will validate the user value here.
+	if isProjectAccessTokenCreated(d) && d.NewValueKnown("rate_limit_window_size") {
+		if v, ok := d.GetOk("rate_limit_window_size"); ok {
+			return validateWinSizeOnCreation(v.(int))
+		}
+	}
+
+	return nil
+}
+
+// isProjectAccessTokenCreated checks if a project access token is created by the plan, either because it is new
+// or because it is replaced.
+func isProjectAccessTokenCreated(d *schema.ResourceDiff) bool {
+	if d.Id() == "" {
+		return true
+	}
+
+	for _, key := range []string{"project_id", "name", "scopes", "rotation.0.keepers"} {
+		if d.HasChange(key) {
+			return true
+		}
+	}
+
+	return isRotationDue(d.Get("date_created").(int), d.Get("rotation.0.rotate_after_days").(int), time.Now())
 }
 
 func validateWinSizeOnCreation(i int) error {
-	supportedWinSizeOnCreate := []int{0, 60, 300, 1800, 3600, 86400, 604800, 2592000}
 	for _, v := range supportedWinSizeOnCreate {
 		if v == i {
 			return nil
@@ -178,14 +388,16 @@ func validateWinSizeOnCreation(i int) error {
 		"Valid values are: %+v", i, supportedWinSizeOnCreate)
 }
 
-func resourceRollbarProjectAccessTokenRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*Config).API
+func resourceRollbarProjectAccessTokenRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	projectID := getProjectID(ctx, d)
 
-	projectID := getProjectID(d)
+	pat, response, getErr := findProjectAccessToken(ctx, meta.(*Config), projectID, getAccessToken(d))
+	if removeFromStateIfNotFound(ctx, d, "rollbar_project_access_token", response, getErr) {
+		return nil
+	}
 
-	pat, _, getErr := client.ProjectAccessTokens.Get(projectID, getAccessToken(d))
 	if getErr != nil {
-		return getErr
+		return diag.FromErr(getErr)
 	}
 
 	d.Set("project_id", pat.GetProjectID())
@@ -201,63 +413,199 @@ func resourceRollbarProjectAccessTokenRead(d *schema.ResourceData, meta interfac
 	return nil
 }
 
-func resourceRollbarProjectAccessTokenUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*Config).API
-	opts := &rollrest.PATUpdateRequest{}
+func resourceRollbarProjectAccessTokenUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Changes to on_destroy and the rotation block other than its keepers only exist in state.
+	if !d.HasChanges("status", "rate_limit_window_size", "rate_limit_window_count") {
+		return resourceRollbarProjectAccessTokenRead(ctx, d, meta)
+	}
+
+	opts := &projectAccessTokenUpdateRequest{}
+
+	if d.HasChange("status") {
+		vs := d.Get("status").(string)
+		logDebug(ctx, "project access token status", map[string]interface{}{"status": vs})
+		opts.Status = vs
+	}
 
 	if v, ok := d.GetOk("rate_limit_window_size"); ok {
 		vs := v.(int)
-		log.Printf("[DEBUG] project access token rate_limit_window_size : %d", vs)
+		logDebug(ctx, "project access token rate_limit_window_size", map[string]interface{}{"rate_limit_window_size": vs})
 		opts.RateLimitWindowSize = vs
 	}
 
 	if v, ok := d.GetOk("rate_limit_window_count"); ok {
 		vs := v.(int)
-		log.Printf("[DEBUG] project access token rate_limit_window_count : %d", vs)
+		logDebug(ctx, "project access token rate_limit_window_count", map[string]interface{}{"rate_limit_window_count": vs})
 		opts.RateLimitWindowCount = vs
 	}
-	pat, _, updateErr := client.ProjectAccessTokens.Update(getProjectID(d), getAccessToken(d), opts)
+
+	pat, _, updateErr := meta.(*Config).updateProjectAccessToken(ctx, getProjectID(ctx, d), getAccessToken(d), opts)
 	if updateErr != nil {
-		return updateErr
+		return diag.FromErr(updateErr)
 	}
 
-	log.Printf("[DEBUG] Updated project access token %s", pat.GetResult().GetName())
+	logDebug(ctx, "Updated project access token", map[string]interface{}{"name": pat.GetResult().GetName()})
 
-	return resourceRollbarProjectAccessTokenRead(d, meta)
+	return resourceRollbarProjectAccessTokenRead(ctx, d, meta)
 }
 
-func resourceRollbarProjectAccessTokenDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*Config).API
+func resourceRollbarProjectAccessTokenDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// on_destroy is read from state so the behaviour also applies once the resource is removed from the configuration.
+	onDestroy := d.Get("on_destroy").(string)
+
+	if onDestroy == projectAccessTokenOnDestroyAbandon {
+		logDebug(ctx, "Removing project access token from state. The token remains unchanged remotely",
+			map[string]interface{}{"name": d.Get("name").(string)})
 
-	// Set access token rate limits to 1 call per 2592000 seconds in order to 'invalidate' them
-	// as the Rollbar API does not support token deletions. A rate limit of 0 calls is not possible.
+		d.SetId("")
+
+		return nil
+	}
+
+	// A rotated token keeps working during the grace period so its consumers can switch to the new token.
+	if waitErr := waitForRotationGracePeriod(ctx, d, meta.(*Config)); waitErr != nil {
+		return diag.FromErr(waitErr)
+	}
+
+	// The Rollbar API does not support token deletions, so the token is either disabled or its rate limits
+	// are set to 1 call per 30 days in order to 'invalidate' it.
 	// Then remove the resource from state. The tokens will need to be removed manually in the UI afterwards.
-	opts := &rollrest.PATUpdateRequest{}
+	opts := &projectAccessTokenUpdateRequest{}
 
-	opts.RateLimitWindowCount = 1
-	opts.RateLimitWindowSize = 2592000
+	if onDestroy == projectAccessTokenOnDestroyDisable {
+		opts.Status = "disabled"
+	} else {
+		opts.RateLimitWindowCount = neutralizedRateLimitWindowCount
+		opts.RateLimitWindowSize = neutralizedRateLimitWindowSize
+	}
 
-	pat, _, updateErr := client.ProjectAccessTokens.Update(getProjectID(d), getAccessToken(d), opts)
+	pat, _, updateErr := meta.(*Config).updateProjectAccessToken(ctx, getProjectID(ctx, d), getAccessToken(d), opts)
 	if updateErr != nil {
-		return updateErr
+		return diag.FromErr(updateErr)
 	}
 
-	log.Printf("[DEBUG] Updated project access token %s", pat.GetResult().GetName())
+	logDebug(ctx, "Updated project access token", map[string]interface{}{"name": pat.GetResult().GetName()})
 
 	d.SetId("")
 
 	return nil
 }
 
+// planProjectAccessTokenRotation plans the replacement of a project access token once its rotation is due.
+func planProjectAccessTokenRotation(ctx context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	rotateAfterDays := d.Get("rotation.0.rotate_after_days").(int)
+	if !isRotationDue(d.Get("date_created").(int), rotateAfterDays, time.Now()) {
+		return nil
+	}
+
+	logDebug(ctx, "Project access token is due for rotation", map[string]interface{}{"name": d.Get("name").(string)})
+
+	// The replacement token has a new creation date, so the change of date_created drives the replacement.
+	if err := d.SetNewComputed("date_created"); err != nil {
+		return err
+	}
+
+	return d.ForceNew("date_created")
+}
+
+// isRotationDue checks if a token created at dateCreated, in seconds since epoch, is older than rotateAfterDays.
+func isRotationDue(dateCreated, rotateAfterDays int, now time.Time) bool {
+	if dateCreated == 0 || rotateAfterDays == 0 {
+		return false
+	}
+
+	return !now.Before(time.Unix(int64(dateCreated), 0).AddDate(0, 0, rotateAfterDays))
+}
+
+// waitForRotationGracePeriod waits for the grace period of a project access token's rotation block
+// if the token has been replaced.
+//
+// The token is considered replaced if the project has a newer token with the same name, which is the case
+// when the replacement token is created before the old token is deleted.
+// The grace period must be shorter than the delete timeout as the wait is bound to it.
+func waitForRotationGracePeriod(ctx context.Context, d *schema.ResourceData, config *Config) error {
+	v, ok := d.GetOk("rotation.0.grace_period")
+	if !ok {
+		return nil
+	}
+
+	gracePeriod, _ := time.ParseDuration(v.(string))
+	if gracePeriod <= 0 {
+		return nil
+	}
+
+	if deleteTimeout := d.Timeout(schema.TimeoutDelete); gracePeriod >= deleteTimeout {
+		return fmt.Errorf("the rotation grace period of %s must be shorter than the delete timeout of %s. "+
+			"Please increase the delete timeout in the timeouts block of the resource", gracePeriod, deleteTimeout)
+	}
+
+	name := d.Get("name").(string)
+
+	pats, _, listErr := config.listProjectAccessTokens(ctx, getProjectID(ctx, d))
+	if listErr != nil {
+		return listErr
+	}
+
+	replaced := false
+	for _, pat := range pats {
+		if pat.GetName() == name && pat.GetAccessToken() != getAccessToken(d) &&
+			int(pat.GetDataCreated()) >= d.Get("date_created").(int) {
+			replaced = true
+			break
+		}
+	}
+
+	if !replaced {
+		logDebug(ctx, "Project access token has not been replaced. Skipping the rotation grace period",
+			map[string]interface{}{"name": name})
+		return nil
+	}
+
+	logDebug(ctx, "Waiting for the rotation grace period before neutralizing project access token",
+		map[string]interface{}{"name": name, "grace_period": gracePeriod.String()})
+
+	timer := time.NewTimer(gracePeriod)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// findProjectAccessToken retrieves a single project access token by its value.
+//
+// A NotFoundError is returned if the project does not have the access token.
+func findProjectAccessToken(ctx context.Context, config *Config, projectID int,
+	accessToken string) (*rollrest.ProjectAccessToken, *simpleresty.Response, error) {
+	pats, response, listErr := config.listProjectAccessTokens(ctx, projectID)
+	if listErr != nil {
+		return nil, response, listErr
+	}
+
+	for _, pat := range pats {
+		if pat.GetAccessToken() == accessToken {
+			return pat, response, nil
+		}
+	}
+
+	return nil, response, NotFoundError{fmt.Errorf("project access token not found in project %d", projectID)}
+}
+
 // getAccessToken is a helper method to get the access token.
 //
 // This should only be used for this resource's update function.
 func getAccessToken(d *schema.ResourceData) string {
 	var accessToken string
 	if v, ok := d.GetOk("access_token"); ok {
-		vs := v.(string)
-		log.Printf("[DEBUG] access_token: %s", vs)
-		accessToken = vs
+		accessToken = v.(string)
+		registerSecret(accessToken)
 	}
 
 	return accessToken
@@ -0,0 +1,102 @@
+package rollbar
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceRollbarProjectAccessTokenV0 returns the schema of rollbar_project_access_token before the resource ID
+// was derived from the project ID and token name.
+func resourceRollbarProjectAccessTokenV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"scopes": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"rate_limit_window_size": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+
+			"rate_limit_window_count": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+
+			"cur_rate_limit_window_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"date_created": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"access_token": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+// resourceRollbarProjectAccessTokenStateUpgradeV0 replaces the random resource ID of version 0
+// with the ID derived from the project ID and token name.
+func resourceRollbarProjectAccessTokenStateUpgradeV0(ctx context.Context, rawState map[string]interface{},
+	_ interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	name, _ := rawState["name"].(string)
+	if name == "" {
+		return nil, fmt.Errorf("unable to upgrade the state of project access token %v: the name is missing",
+			rawState["id"])
+	}
+
+	var projectID int
+	switch v := rawState["project_id"].(type) {
+	case float64:
+		projectID = int(v)
+	case int:
+		projectID = v
+	case string:
+		projectID = StringToInt(v)
+	}
+
+	if projectID == 0 {
+		return nil, fmt.Errorf("unable to upgrade the state of project access token %s: the project ID is missing",
+			name)
+	}
+
+	id := projectAccessTokenID(projectID, name)
+	logDebug(ctx, "Upgrading the ID of project access token",
+		map[string]interface{}{"old_id": rawState["id"], "id": id})
+
+	rawState["id"] = id
+
+	return rawState, nil
+}
@@ -0,0 +1,61 @@
+package rollbar
+
+import (
+	"context"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestResourceRollbarProjectAccessTokenStateUpgradeV0(t *testing.T) {
+	rawState := map[string]interface{}{
+		"id":                      "5577006791947779410",
+		"project_id":              float64(123),
+		"name":                    "deploy",
+		"scopes":                  []interface{}{"write"},
+		"status":                  "enabled",
+		"rate_limit_window_size":  float64(60),
+		"rate_limit_window_count": float64(100),
+		"access_token":            "secret",
+	}
+
+	upgraded, err := resourceRollbarProjectAccessTokenStateUpgradeV0(context.Background(), rawState, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "123:deploy", upgraded["id"])
+	assert.Equal(t, "deploy", upgraded["name"])
+	assert.Equal(t, "secret", upgraded["access_token"])
+
+	_, err = resourceRollbarProjectAccessTokenStateUpgradeV0(context.Background(), map[string]interface{}{
+		"id":         "5577006791947779410",
+		"project_id": float64(123),
+	}, nil)
+
+	assert.NotNil(t, err)
+}
+
+func TestResourceRollbarProjectAccessToken_StateUpgraders(t *testing.T) {
+	r := resourceRollbarProjectAccessToken()
+
+	assert.Equal(t, 1, r.SchemaVersion)
+	if !assert.Len(t, r.StateUpgraders, 1) {
+		return
+	}
+	assert.Equal(t, 0, r.StateUpgraders[0].Version)
+
+	// A state written before the upgrade must match the type of version 0.
+	_, err := ctyjson.Unmarshal([]byte(`{
+		"id": "5577006791947779410",
+		"project_id": 123,
+		"name": "deploy",
+		"scopes": ["write"],
+		"status": "enabled",
+		"rate_limit_window_size": 60,
+		"rate_limit_window_count": 100,
+		"cur_rate_limit_window_count": 0,
+		"date_created": 1600000000,
+		"access_token": "secret"
+	}`), r.StateUpgraders[0].Type)
+
+	assert.Nil(t, err)
+}
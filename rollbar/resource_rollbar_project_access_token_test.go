@@ -1,11 +1,20 @@
 package rollbar
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
 	"regexp"
+	"strconv"
 	"testing"
+	"time"
 )
 
 func TestAccRollbarProjectAccessToken_Basic(t *testing.T) {
@@ -34,6 +43,52 @@ func TestAccRollbarProjectAccessToken_Basic(t *testing.T) {
 						"rollbar_project_access_token.foobar", "rate_limit_window_size", "131"),
 				),
 			},
+			{
+				Config: testAccCheckRollbarProjectAccessToken_Disabled(projectName, tokenName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"rollbar_project_access_token.foobar", "status", "disabled"),
+					resource.TestCheckResourceAttr(
+						"rollbar_project_access_token.foobar", "rate_limit_window_size", "131"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRollbarProjectAccessToken_RotationKeepers(t *testing.T) {
+	projectName := fmt.Sprintf("project-tftest-%s", acctest.RandString(10))
+	tokenName := fmt.Sprintf("token-tftest-%s", acctest.RandString(10))
+	var accessToken string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckRollbarProjectAccessToken_rotation(projectName, tokenName, "v1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"rollbar_project_access_token.foobar", "rotation.0.keepers.release", "v1"),
+					func(s *terraform.State) error {
+						accessToken = s.RootModule().Resources["rollbar_project_access_token.foobar"].Primary.Attributes["access_token"]
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccCheckRollbarProjectAccessToken_rotation(projectName, tokenName, "v2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"rollbar_project_access_token.foobar", "rotation.0.keepers.release", "v2"),
+					func(s *terraform.State) error {
+						if s.RootModule().Resources["rollbar_project_access_token.foobar"].Primary.Attributes["access_token"] == accessToken {
+							return fmt.Errorf("expected the project access token to be rotated")
+						}
+						return nil
+					},
+				),
+			},
 		},
 	})
 }
@@ -70,10 +125,223 @@ func TestAccRollbarProjectAccessToken_InvalidWinSizeOnCreation(t *testing.T) {
 	})
 }
 
+func TestIsRotationDue(t *testing.T) {
+	now := time.Date(2021, 3, 10, 12, 0, 0, 0, time.UTC)
+	created := int(now.AddDate(0, 0, -30).Unix())
+
+	assert.True(t, isRotationDue(created, 30, now))
+	assert.False(t, isRotationDue(created, 31, now))
+	assert.False(t, isRotationDue(created, 0, now))
+	assert.False(t, isRotationDue(0, 30, now))
+}
+
+func TestResourceRollbarProjectAccessTokenDiff_Rotation(t *testing.T) {
+	config := newTestConfig(t, "http://127.0.0.1:0")
+	created := time.Now().AddDate(0, 0, -10).Unix()
+
+	state := &terraform.InstanceState{
+		ID: "123",
+		Attributes: map[string]string{
+			"id":                           "123",
+			"project_id":                   "1",
+			"name":                         "foobar",
+			"scopes.#":                     "1",
+			"scopes.2339573637":            "read",
+			"status":                       "enabled",
+			"rate_limit_window_size":       "60",
+			"rate_limit_window_count":      "1500",
+			"date_created":                 strconv.FormatInt(created, 10),
+			"rotation.#":                   "1",
+			"rotation.0.rotate_after_days": "30",
+			"rotation.0.keepers.%":         "1",
+			"rotation.0.keepers.release":   "v1",
+			"rotation.0.grace_period":      "0s",
+			"access_token":                 "secret",
+			"cur_rate_limit_window_count":  "0",
+		},
+	}
+
+	diffFor := func(rotateAfterDays int, release string) *terraform.InstanceDiff {
+		raw := map[string]interface{}{
+			"project_id":              1,
+			"name":                    "foobar",
+			"scopes":                  []interface{}{"read"},
+			"status":                  "enabled",
+			"rate_limit_window_size":  60,
+			"rate_limit_window_count": 1500,
+			"rotation": []interface{}{map[string]interface{}{
+				"rotate_after_days": rotateAfterDays,
+				"keepers":           map[string]interface{}{"release": release},
+			}},
+		}
+
+		diff, diffErr := resourceRollbarProjectAccessToken().SimpleDiff(context.Background(), state,
+			terraform.NewResourceConfigRaw(raw), config)
+		if !assert.Nil(t, diffErr) {
+			t.FailNow()
+		}
+
+		return diff
+	}
+
+	notDue := diffFor(30, "v1")
+	assert.True(t, notDue == nil || !notDue.RequiresNew())
+
+	due := diffFor(10, "v1")
+	if assert.NotNil(t, due) {
+		assert.True(t, due.RequiresNew())
+		assert.True(t, due.Attributes["date_created"].RequiresNew)
+	}
+
+	keepersChanged := diffFor(30, "v2")
+	if assert.NotNil(t, keepersChanged) {
+		assert.True(t, keepersChanged.RequiresNew())
+	}
+}
+
+func TestResourceRollbarProjectAccessTokenDiff_Validation(t *testing.T) {
+	config := newTestConfig(t, "http://127.0.0.1:0")
+
+	diffErr := func(raw map[string]interface{}) error {
+		raw["project_id"] = 1
+		raw["name"] = "foobar"
+		raw["scopes"] = []interface{}{"read"}
+		raw["status"] = "enabled"
+
+		_, err := resourceRollbarProjectAccessToken().SimpleDiff(context.Background(), nil,
+			terraform.NewResourceConfigRaw(raw), config)
+		return err
+	}
+
+	assert.Nil(t, diffErr(map[string]interface{}{"rate_limit_window_size": 60, "rate_limit_window_count": 1500}))
+
+	err := diffErr(map[string]interface{}{"rate_limit_window_size": 59})
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(),
+			"59 is not a supported window size for token creation. Valid values are: [0 60 300 1800 3600 86400 604800 2592000]")
+	}
+
+	err = diffErr(map[string]interface{}{"rate_limit_window_count": 1500})
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "rate_limit_window_count requires rate_limit_window_size to be set")
+	}
+
+	err = diffErr(map[string]interface{}{"rotation": []interface{}{map[string]interface{}{"grace_period": "5m"}}})
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "the rotation block requires rotate_after_days or keepers to be set")
+	}
+
+	err = diffErr(map[string]interface{}{"rotation": []interface{}{map[string]interface{}{
+		"rotate_after_days": 30,
+		"grace_period":      "10m",
+	}}})
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "the rotation grace period of 10m0s must be shorter than the delete timeout of 5m0s")
+	}
+}
+
+func TestWaitForRotationGracePeriod(t *testing.T) {
+	replaced := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if replaced {
+			w.Write([]byte(`{"err": 0, "result": [
+				{"project_id": 1, "name": "foobar", "access_token": "old-token", "date_created": 1600000000},
+				{"project_id": 1, "name": "foobar", "access_token": "new-token", "date_created": 1600000100}
+			]}`))
+			return
+		}
+
+		w.Write([]byte(`{"err": 0, "result": [
+			{"project_id": 1, "name": "foobar", "access_token": "old-token", "date_created": 1600000000}
+		]}`))
+	}))
+	defer server.Close()
+
+	config := newTestConfig(t, server.URL)
+
+	d := schema.TestResourceDataRaw(t, resourceRollbarProjectAccessToken().Schema, map[string]interface{}{
+		"project_id":   1,
+		"name":         "foobar",
+		"access_token": "old-token",
+		"date_created": 1600000000,
+		"rotation":     []interface{}{map[string]interface{}{"grace_period": "10m"}},
+	})
+
+	// A token that is destroyed without being replaced is neutralized right away.
+	assert.Nil(t, waitForRotationGracePeriod(context.Background(), d, config))
+
+	replaced = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+	assert.Equal(t, context.Canceled, waitForRotationGracePeriod(ctx, d, config))
+
+	// Without configured timeouts, the delete timeout defaults to 20 minutes.
+	d.Set("rotation", []interface{}{map[string]interface{}{"grace_period": "30m"}})
+	err := waitForRotationGracePeriod(context.Background(), d, config)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "must be shorter than the delete timeout")
+	}
+
+	d.Set("rotation", []interface{}{map[string]interface{}{"grace_period": "10ms"}})
+	assert.Nil(t, waitForRotationGracePeriod(context.Background(), d, config))
+}
+
+func TestResourceRollbarProjectAccessTokenDelete(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/project/1/access_token/secret", r.URL.Path)
+
+		body = nil
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		w.Write([]byte(`{"err": 0, "result": {"name": "foobar"}}`))
+	}))
+	defer server.Close()
+
+	config := newTestConfig(t, server.URL)
+
+	d := schema.TestResourceDataRaw(t, resourceRollbarProjectAccessToken().Schema, map[string]interface{}{
+		"project_id":   1,
+		"name":         "foobar",
+		"access_token": "secret",
+	})
+	d.SetId("123")
+
+	assert.False(t, resourceRollbarProjectAccessTokenDelete(context.Background(), d, config).HasError())
+	assert.Equal(t, map[string]interface{}{
+		"rate_limit_window_count": float64(neutralizedRateLimitWindowCount),
+		"rate_limit_window_size":  float64(neutralizedRateLimitWindowSize),
+	}, body)
+	assert.Equal(t, "", d.Id())
+
+	d.SetId("123")
+	d.Set("on_destroy", "disable")
+
+	assert.False(t, resourceRollbarProjectAccessTokenDelete(context.Background(), d, config).HasError())
+	assert.Equal(t, map[string]interface{}{"status": "disabled"}, body)
+
+	body = nil
+	d.SetId("123")
+	d.Set("on_destroy", "abandon")
+
+	assert.False(t, resourceRollbarProjectAccessTokenDelete(context.Background(), d, config).HasError())
+	assert.Nil(t, body)
+	assert.Equal(t, "", d.Id())
+}
+
 func testAccCheckRollbarProjectAccessToken_basic(projectName, tokenName string) string {
 	return fmt.Sprintf(`
 resource "rollbar_project" "foobar" {
 	name = "%s"
+	deletion_protection = false
 }
 
 resource "rollbar_project_access_token" "foobar" {
@@ -91,6 +359,7 @@ func testAccCheckRollbarProjectAccessToken_InvalidScopes(projectName, tokenName
 	return fmt.Sprintf(`
 resource "rollbar_project" "foobar" {
 	name = "%s"
+	deletion_protection = false
 }
 
 resource "rollbar_project_access_token" "foobar" {
@@ -108,6 +377,7 @@ func testAccCheckRollbarProjectAccessToken_InvalidWinSize(projectName, tokenName
 	return fmt.Sprintf(`
 resource "rollbar_project" "foobar" {
 	name = "%s"
+	deletion_protection = false
 }
 
 resource "rollbar_project_access_token" "foobar" {
@@ -125,6 +395,7 @@ func testAccCheckRollbarProjectAccessToken_NewWinSize(projectName, tokenName str
 	return fmt.Sprintf(`
 resource "rollbar_project" "foobar" {
 	name = "%s"
+	deletion_protection = false
 }
 
 resource "rollbar_project_access_token" "foobar" {
@@ -137,3 +408,50 @@ resource "rollbar_project_access_token" "foobar" {
 }
 `, projectName, tokenName)
 }
+
+func testAccCheckRollbarProjectAccessToken_Disabled(projectName, tokenName string) string {
+	return fmt.Sprintf(`
+resource "rollbar_project" "foobar" {
+	name = "%s"
+	deletion_protection = false
+}
+
+resource "rollbar_project_access_token" "foobar" {
+	project_id = rollbar_project.foobar.id
+	name = "%s"
+	scopes = ["read"]
+	status = "disabled"
+	rate_limit_window_size = 131
+	rate_limit_window_count = 1500
+	on_destroy = "disable"
+}
+`, projectName, tokenName)
+}
+
+func testAccCheckRollbarProjectAccessToken_rotation(projectName, tokenName, release string) string {
+	return fmt.Sprintf(`
+resource "rollbar_project" "foobar" {
+	name = "%s"
+	deletion_protection = false
+}
+
+resource "rollbar_project_access_token" "foobar" {
+	project_id = rollbar_project.foobar.id
+	name = "%s"
+	scopes = ["read"]
+	status = "enabled"
+
+	rotation {
+		rotate_after_days = 30
+		grace_period = "5s"
+		keepers = {
+			release = "%s"
+		}
+	}
+
+	lifecycle {
+		create_before_destroy = true
+	}
+}
+`, projectName, tokenName, release)
+}
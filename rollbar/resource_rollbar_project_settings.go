@@ -0,0 +1,208 @@
+package rollbar
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"strconv"
+	"time"
+)
+
+func resourceRollbarProjectSettings() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRollbarProjectSettingsCreate,
+		ReadContext:   resourceRollbarProjectSettingsRead,
+		UpdateContext: resourceRollbarProjectSettingsUpdate,
+		DeleteContext: resourceRollbarProjectSettingsDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRollbarProjectSettingsImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		CustomizeDiff: requireAccountAccessToken("rollbar_project_settings"),
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"timezone": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"default_environment": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"ip_address_collection": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"full", "anonymized", "disabled"}, false),
+			},
+
+			"scrub_fields": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"occurrence_sampling_rate": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.FloatBetween(0, 1),
+			},
+		},
+	}
+}
+
+func resourceRollbarProjectSettingsImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, err := strconv.Atoi(d.Id()); err != nil {
+		return nil, fmt.Errorf("expected the project ID as the import ID, got %s", d.Id())
+	}
+
+	if readDiags := resourceRollbarProjectSettingsRead(ctx, d, meta); readDiags.HasError() {
+		return nil, fmt.Errorf("unable to import project settings: %s", readDiags[0].Summary)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceRollbarProjectSettingsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	projectID := getProjectID(ctx, d)
+
+	// Project settings always exist, so creating the resource updates the settings that are configured.
+	if updateErr := updateProjectSettings(ctx, d, meta.(*Config), projectID); updateErr != nil {
+		return diag.FromErr(updateErr)
+	}
+
+	d.SetId(strconv.Itoa(projectID))
+
+	return resourceRollbarProjectSettingsRead(ctx, d, meta)
+}
+
+func resourceRollbarProjectSettingsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	project, response, getErr := meta.(*Config).getProjectSettings(ctx, StringToInt(d.Id()))
+
+	// Deleted projects are still returned by the API but without a name.
+	if getErr == nil && (project.Result == nil || project.Result.Name == nil || *project.Result.Name == "") {
+		getErr = NotFoundError{fmt.Errorf("project %s has been deleted", d.Id())}
+	}
+
+	if removeFromStateIfNotFound(ctx, d, "rollbar_project_settings", response, getErr) {
+		return nil
+	}
+
+	if getErr != nil {
+		return diag.FromErr(getErr)
+	}
+
+	settings := project.Result.SettingsData
+	if settings == nil {
+		settings = &projectSettings{}
+	}
+
+	d.Set("project_id", StringToInt(d.Id()))
+	d.Set("timezone", stringValue(settings.Timezone))
+	d.Set("default_environment", stringValue(settings.DefaultEnvironment))
+	d.Set("ip_address_collection", stringValue(settings.IPAddressCollection))
+
+	var scrubFields []string
+	if settings.ScrubFields != nil {
+		scrubFields = *settings.ScrubFields
+	}
+	d.Set("scrub_fields", scrubFields)
+
+	var samplingRate float64
+	if settings.SamplingRate != nil {
+		samplingRate = *settings.SamplingRate
+	}
+	d.Set("occurrence_sampling_rate", samplingRate)
+
+	return nil
+}
+
+func resourceRollbarProjectSettingsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if updateErr := updateProjectSettings(ctx, d, meta.(*Config), StringToInt(d.Id())); updateErr != nil {
+		return diag.FromErr(updateErr)
+	}
+
+	return resourceRollbarProjectSettingsRead(ctx, d, meta)
+}
+
+func resourceRollbarProjectSettingsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Project settings cannot be deleted, so the settings are left as is and the resource is only removed from state.
+	logDebug(ctx, "Removing project settings from state. The settings remain unchanged remotely",
+		map[string]interface{}{"project_id": d.Id()})
+
+	d.SetId("")
+
+	return nil
+}
+
+// updateProjectSettings sends the configured settings of a project.
+//
+// Settings that are not set in the configuration are left unchanged so they can still be managed in the UI.
+func updateProjectSettings(ctx context.Context, d *schema.ResourceData, config *Config, projectID int) error {
+	settings := &projectSettings{}
+
+	if isConfigured(d, "timezone") {
+		v := d.Get("timezone").(string)
+		settings.Timezone = &v
+	}
+
+	if isConfigured(d, "default_environment") {
+		v := d.Get("default_environment").(string)
+		settings.DefaultEnvironment = &v
+	}
+
+	if isConfigured(d, "ip_address_collection") {
+		v := d.Get("ip_address_collection").(string)
+		settings.IPAddressCollection = &v
+	}
+
+	if isConfigured(d, "scrub_fields") {
+		v := make([]string, 0)
+		for _, field := range d.Get("scrub_fields").(*schema.Set).List() {
+			v = append(v, field.(string))
+		}
+		settings.ScrubFields = &v
+	}
+
+	if isConfigured(d, "occurrence_sampling_rate") {
+		v := d.Get("occurrence_sampling_rate").(float64)
+		settings.SamplingRate = &v
+	}
+
+	logDebug(ctx, "Updating project settings", map[string]interface{}{"project_id": projectID})
+
+	_, _, updateErr := config.updateProjectSettings(ctx, projectID, &projectSettingsRequest{SettingsData: settings})
+	if updateErr != nil {
+		return updateErr
+	}
+
+	logDebug(ctx, "Updated project settings", map[string]interface{}{"project_id": projectID})
+
+	return nil
+}
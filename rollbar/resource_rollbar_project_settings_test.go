@@ -0,0 +1,63 @@
+package rollbar
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+func TestAccRollbarProjectSettings_Basic(t *testing.T) {
+	name := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckRollbarProjectSettings_basic(name, "UTC", "production"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"rollbar_project_settings.foobar", "project_id", "rollbar_project.foobar", "id"),
+					resource.TestCheckResourceAttr(
+						"rollbar_project_settings.foobar", "timezone", "UTC"),
+					resource.TestCheckResourceAttr(
+						"rollbar_project_settings.foobar", "default_environment", "production"),
+					resource.TestCheckResourceAttr(
+						"rollbar_project_settings.foobar", "ip_address_collection", "anonymized"),
+					resource.TestCheckResourceAttr(
+						"rollbar_project_settings.foobar", "scrub_fields.#", "2"),
+					resource.TestCheckResourceAttr(
+						"rollbar_project_settings.foobar", "occurrence_sampling_rate", "0.5"),
+				),
+			},
+			{
+				Config: testAccCheckRollbarProjectSettings_basic(name, "America/Los_Angeles", "staging"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"rollbar_project_settings.foobar", "timezone", "America/Los_Angeles"),
+					resource.TestCheckResourceAttr(
+						"rollbar_project_settings.foobar", "default_environment", "staging"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckRollbarProjectSettings_basic(name, timezone, environment string) string {
+	return fmt.Sprintf(`
+resource "rollbar_project" "foobar" {
+	name = "%s"
+	deletion_protection = false
+}
+
+resource "rollbar_project_settings" "foobar" {
+	project_id = rollbar_project.foobar.id
+	timezone = "%s"
+	default_environment = "%s"
+	ip_address_collection = "anonymized"
+	scrub_fields = ["password", "secret"]
+	occurrence_sampling_rate = 0.5
+}
+`, name, timezone, environment)
+}
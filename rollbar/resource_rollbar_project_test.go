@@ -1,9 +1,19 @@
 package rollbar
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"github.com/davidji99/rollrest-go/rollrest"
+	helper "github.com/davidji99/terraform-provider-rollbar/helper/test"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
 	"regexp"
 	"testing"
 )
@@ -20,12 +30,216 @@ func TestAccRollbarProject_Basic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(
 						"rollbar_project.foobar", "name", name),
+					resource.TestCheckResourceAttrSet(
+						"rollbar_project.foobar", "read_access_token"),
+					resource.TestCheckResourceAttrSet(
+						"rollbar_project.foobar", "post_server_item_access_token"),
 				),
 			},
 		},
 	})
 }
 
+func TestAccRollbarProject_Rename(t *testing.T) {
+	name := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+	newName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+	var projectID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckRollbarProject_basic(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRollbarProjectID("rollbar_project.foobar", &projectID),
+				),
+			},
+			{
+				Config: testAccCheckRollbarProject_basic(newName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"rollbar_project.foobar", "name", newName),
+					resource.TestCheckResourceAttrPtr(
+						"rollbar_project.foobar", "id", &projectID),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRollbarProject_AdoptExisting(t *testing.T) {
+	name := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { testAccCreateRollbarProject(t, name) },
+				Config:    testAccCheckRollbarProject_adoptExisting(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"rollbar_project.foobar", "name", name),
+					resource.TestCheckResourceAttr(
+						"rollbar_project.foobar", "adopted", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceRollbarProjectCreate_AdoptExisting(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/projects":
+			w.Write([]byte(`{"err": 0, "result": [{"id": 123, "name": "foobar", "status": "enabled"}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/project/123":
+			w.Write([]byte(`{"err": 0, "result": {"id": 123, "name": "foobar", "status": "enabled"}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/project/123/access_tokens":
+			w.Write([]byte(`{"err": 0, "result": [{"project_id": 123, "name": "read", "access_token": "read-token",
+				"status": "enabled", "scopes": ["read"]}]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	config := newTestConfig(t, server.URL)
+
+	d := schema.TestResourceDataRaw(t, resourceRollbarProject().Schema, map[string]interface{}{
+		"name":           "foobar",
+		"adopt_existing": true,
+	})
+
+	diags := resourceRollbarProjectCreate(context.Background(), d, config)
+
+	assert.False(t, diags.HasError())
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, diag.Warning, diags[0].Severity)
+		assert.Equal(t, "Adopted existing project foobar", diags[0].Summary)
+	}
+	assert.Equal(t, "123", d.Id())
+	assert.True(t, d.Get("adopted").(bool))
+	assert.Equal(t, "read-token", d.Get("read_access_token"))
+	assert.Equal(t, "", d.Get("write_access_token"))
+}
+
+func TestResourceRollbarProjectRead_DefaultTokens(t *testing.T) {
+	tokenRequests := 0
+	failTokens := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/project/123":
+			w.Write([]byte(`{"err": 0, "result": {"id": 123, "name": "foobar", "status": "enabled"}}`))
+		case "/project/123/access_tokens":
+			tokenRequests++
+			if failTokens {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.Write([]byte(`{"err": 0, "result": [
+				{"project_id": 123, "name": "read", "access_token": "user-read-token", "status": "enabled",
+					"scopes": ["read"], "date_created": 1600000100},
+				{"project_id": 123, "name": "read", "access_token": "read-token", "status": "enabled",
+					"scopes": ["read"], "date_created": 1600000000},
+				{"project_id": 123, "name": "write", "access_token": "disabled-write-token", "status": "disabled",
+					"scopes": ["write"], "date_created": 1600000000},
+				{"project_id": 123, "name": "post_server_item", "access_token": "neutralized-token", "status": "enabled",
+					"scopes": ["post_server_item"], "rate_limit_window_count": 1, "rate_limit_window_size": 2592000,
+					"date_created": 1600000000},
+				{"project_id": 123, "name": "post_client_item", "access_token": "client-token", "status": "enabled",
+					"scopes": ["post_client_item", "read"], "date_created": 1600000000}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	config := newTestConfig(t, server.URL)
+
+	d := schema.TestResourceDataRaw(t, resourceRollbarProject().Schema, map[string]interface{}{"name": "foobar"})
+	d.SetId("123")
+
+	assert.False(t, resourceRollbarProjectRead(context.Background(), d, config).HasError())
+	assert.Equal(t, "read-token", d.Get("read_access_token"))
+	assert.Equal(t, "", d.Get("write_access_token"))
+	assert.Equal(t, "", d.Get("post_server_item_access_token"))
+	assert.Equal(t, "", d.Get("post_client_item_access_token"))
+	assert.NotZero(t, tokenRequests)
+
+	// A failure to list the tokens does not fail the project read.
+	failTokens = true
+
+	assert.False(t, resourceRollbarProjectRead(context.Background(), d, config).HasError())
+	assert.Equal(t, "foobar", d.Get("name"))
+	assert.Equal(t, "read-token", d.Get("read_access_token"))
+
+	// The tokens are not listed once all of them are known.
+	tokenRequests = 0
+	for _, name := range defaultProjectAccessTokens {
+		d.Set(name+"_access_token", name+"-token")
+	}
+
+	assert.False(t, resourceRollbarProjectRead(context.Background(), d, config).HasError())
+	assert.Equal(t, 0, tokenRequests)
+}
+
+func TestNeutralizeDefaultProjectAccessTokens(t *testing.T) {
+	var updated []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodPatch {
+			var body map[string]interface{}
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, map[string]interface{}{"status": "disabled"}, body)
+
+			updated = append(updated, r.URL.Path)
+			w.Write([]byte(`{"err": 0, "result": {}}`))
+			return
+		}
+
+		w.Write([]byte(`{"err": 0, "result": [
+			{"project_id": 123, "name": "read", "access_token": "read-token", "scopes": ["read"]},
+			{"project_id": 123, "name": "deploy", "access_token": "deploy-token", "scopes": ["write"]},
+			{"project_id": 123, "name": "post_server_item", "access_token": "server-token", "scopes": ["post_server_item"]}
+		]}`))
+	}))
+	defer server.Close()
+
+	config := newTestConfig(t, server.URL)
+
+	err := neutralizeDefaultProjectAccessTokens(context.Background(), config, 123, "disable")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"/project/123/access_token/read-token", "/project/123/access_token/server-token"}, updated)
+}
+
+func TestAccRollbarProject_Disappears(t *testing.T) {
+	name := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckRollbarProject_basic(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRollbarProjectDisappears("rollbar_project.foobar"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
 func TestAccRollbarProject_InvalidName(t *testing.T) {
 	name := "invalid projectname@"
 
@@ -42,10 +256,80 @@ func TestAccRollbarProject_InvalidName(t *testing.T) {
 	})
 }
 
+func TestResourceRollbarProjectDelete_DeletionProtection(t *testing.T) {
+	config := newTestConfig(t, "http://127.0.0.1:0")
+
+	d := schema.TestResourceDataRaw(t, resourceRollbarProject().Schema, map[string]interface{}{"name": "foobar"})
+	d.SetId("123")
+
+	diags := resourceRollbarProjectDelete(context.Background(), d, config)
+	assert.True(t, diags.HasError())
+	assert.Equal(t, "Cannot delete project 123 as deletion protection is enabled", diags[0].Summary)
+	assert.Equal(t, "123", d.Id())
+
+	d.Set("deletion_protection", false)
+	config.ProtectAllProjects = true
+
+	diags = resourceRollbarProjectDelete(context.Background(), d, config)
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Detail, "protect_all_projects")
+}
+
 func testAccCheckRollbarProject_basic(name string) string {
 	return fmt.Sprintf(`
 resource "rollbar_project" "foobar" {
 	name = "%s"
+	deletion_protection = false
 }
 `, name)
 }
+
+func testAccCheckRollbarProject_adoptExisting(name string) string {
+	return fmt.Sprintf(`
+resource "rollbar_project" "foobar" {
+	name = "%s"
+	adopt_existing = true
+	deletion_protection = false
+}
+`, name)
+}
+
+// testAccCreateRollbarProject creates a project outside of Terraform.
+func testAccCreateRollbarProject(t *testing.T, name string) {
+	config := NewConfig()
+	config.accountAccessToken = testAccConfig.Get(helper.TestConfigAccountAccessToken)
+
+	if err := config.initializeAPI(context.Background()); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if _, _, err := config.API.Projects.Create(&rollrest.ProjectRequest{Name: name}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func testAccCheckRollbarProjectDisappears(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		client := testAccProvider.Meta().(*Config).API
+
+		_, deleteErr := client.Projects.Delete(StringToInt(rs.Primary.ID))
+		return deleteErr
+	}
+}
+
+func testAccCheckRollbarProjectID(resourceName string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		*id = rs.Primary.ID
+		return nil
+	}
+}
@@ -1,22 +1,35 @@
 package rollbar
 
 import (
+	"context"
+	"fmt"
 	"github.com/davidji99/rollrest-go/rollrest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	"log"
+	"time"
 )
 
 func resourceRollbarTeam() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceRollbarTeamCreate,
-		Read:   resourceRollbarTeamRead,
-		Delete: resourceRollbarTeamDelete,
+		CreateContext: resourceRollbarTeamCreate,
+		ReadContext:   resourceRollbarTeamRead,
+		UpdateContext: resourceRollbarTeamUpdate,
+		DeleteContext: resourceRollbarTeamDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: resourceRollbarTeamImport,
+			StateContext: resourceRollbarTeamImport,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		CustomizeDiff: requireAccountAccessToken("rollbar_team"),
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -31,6 +44,12 @@ func resourceRollbarTeam() *schema.Resource {
 				ValidateFunc: validation.StringInSlice([]string{"standard", "light", "view"}, false),
 			},
 
+			"deletion_protection": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			"account_id": {
 				Type:     schema.TypeInt,
 				Computed: true,
@@ -39,49 +58,64 @@ func resourceRollbarTeam() *schema.Resource {
 	}
 }
 
-func resourceRollbarTeamImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourceRollbarTeamImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	d.SetId(d.Id())
-	readErr := resourceRollbarTeamRead(d, meta)
+	d.Set("deletion_protection", false)
+
+	if readDiags := resourceRollbarTeamRead(ctx, d, meta); readDiags.HasError() {
+		return nil, fmt.Errorf("unable to import team: %s", readDiags[0].Summary)
+	}
 
-	return []*schema.ResourceData{d}, readErr
+	return []*schema.ResourceData{d}, nil
 }
 
-func resourceRollbarTeamCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*Config).API
+func resourceRollbarTeamCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := meta.(*Config).apiClient(ctx)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	opts := &rollrest.TeamRequest{}
 
 	if v, ok := d.GetOk("name"); ok {
 		vs := v.(string)
-		log.Printf("[DEBUG] team name is : %s", vs)
+		logDebug(ctx, "team name", map[string]interface{}{"name": vs})
 		opts.Name = vs
 	}
 
 	if v, ok := d.GetOk("access_level"); ok {
 		vs := v.(string)
-		log.Printf("[DEBUG] team name is : %s", vs)
+		logDebug(ctx, "team access_level", map[string]interface{}{"access_level": vs})
 		opts.AccessLevel = vs
 	}
 
-	log.Printf("[DEBUG] Creating new team %s", opts.Name)
+	logDebug(ctx, "Creating new team", map[string]interface{}{"name": opts.Name})
 
 	newTeam, _, createErr := client.Teams.Create(opts)
 	if createErr != nil {
-		return createErr
+		return diag.FromErr(createErr)
 	}
 
-	log.Printf("[DEBUG] Created new team %s", opts.Name)
+	logDebug(ctx, "Created new team", map[string]interface{}{"name": opts.Name})
+	meta.(*Config).cache.teams.invalidate()
 
 	d.SetId(Int64ToString(newTeam.GetResult().GetID()))
 
-	return resourceRollbarTeamRead(d, meta)
+	return resourceRollbarTeamRead(ctx, d, meta)
 }
 
-func resourceRollbarTeamRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*Config).API
+func resourceRollbarTeamRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := meta.(*Config).apiClient(ctx)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+
+	team, response, getErr := client.Teams.Get(StringToInt(d.Id()))
+	if removeFromStateIfNotFound(ctx, d, "rollbar_team", response, getErr) {
+		return nil
+	}
 
-	team, _, getErr := client.Teams.Get(StringToInt(d.Id()))
 	if getErr != nil {
-		return getErr
+		return diag.FromErr(getErr)
 	}
 
 	d.Set("name", team.GetResult().GetName())
@@ -91,17 +125,30 @@ func resourceRollbarTeamRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceRollbarTeamDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*Config).API
+func resourceRollbarTeamUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Only deletion_protection can be updated, which is not stored remotely.
+	return resourceRollbarTeamRead(ctx, d, meta)
+}
+
+func resourceRollbarTeamDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := meta.(*Config).apiClient(ctx)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+
+	if d.Get("deletion_protection").(bool) {
+		return diag.Diagnostics{deletionProtectionDiagnostic("team", d.Id(), false)}
+	}
 
-	log.Printf("[DEBUG] Team id to be deleted: %v", d.Id())
+	logDebug(ctx, "Deleting team", map[string]interface{}{"team_id": d.Id()})
 
 	_, deleteErr := client.Teams.Delete(StringToInt(d.Id()))
 	if deleteErr != nil {
-		return deleteErr
+		return diag.FromErr(deleteErr)
 	}
 
-	log.Printf("[DEBUG] Deleted team id : %v", d.Id())
+	logDebug(ctx, "Deleted team", map[string]interface{}{"team_id": d.Id()})
+	meta.(*Config).cache.teams.invalidate()
 	d.SetId("")
 
 	return nil
@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"strconv"
+	"time"
 )
 
 func resourceRollbarTeamProjectAssociation() *schema.Resource {
@@ -18,6 +19,14 @@ func resourceRollbarTeamProjectAssociation() *schema.Resource {
 			StateContext: resourceRollbarTeamProjectAssociationImport,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		CustomizeDiff: requireAccountAccessToken("rollbar_team_project_association"),
+
 		Schema: map[string]*schema.Schema{
 			"team_id": {
 				Type:     schema.TypeInt,
@@ -35,7 +44,10 @@ func resourceRollbarTeamProjectAssociation() *schema.Resource {
 }
 
 func resourceRollbarTeamProjectAssociationImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	client := meta.(*Config).API
+	client, clientErr := meta.(*Config).apiClient(ctx)
+	if clientErr != nil {
+		return nil, clientErr
+	}
 
 	result, _ := ParseCompositeID(d.Id(), 2)
 	teamID, _ := strconv.Atoi(result[0])
@@ -59,9 +71,12 @@ func resourceRollbarTeamProjectAssociationImport(ctx context.Context, d *schema.
 
 func resourceRollbarTeamProjectAssociationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := meta.(*Config).API
-	teamID := getTeamID(d)
-	projectID := getProjectID(d)
+	client, clientErr := meta.(*Config).apiClient(ctx)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	teamID := getTeamID(ctx, d)
+	projectID := getProjectID(ctx, d)
 
 	result, _, assignErr := client.Teams.AssignProject(teamID, projectID)
 	if assignErr != nil {
@@ -80,12 +95,23 @@ func resourceRollbarTeamProjectAssociationCreate(ctx context.Context, d *schema.
 
 func resourceRollbarTeamProjectAssociationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := meta.(*Config).API
+	client, clientErr := meta.(*Config).apiClient(ctx)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
-	teamID := getTeamID(d)
-	projectID := getProjectID(d)
+	teamID := getTeamID(ctx, d)
+	projectID := getProjectID(ctx, d)
+
+	hasProject, response, err := client.Teams.HasProject(teamID, projectID)
+	if err == nil && !hasProject {
+		err = NotFoundError{fmt.Errorf("could not find project %d on team %d", projectID, teamID)}
+	}
+
+	if removeFromStateIfNotFound(ctx, d, "rollbar_team_project_association", response, err) {
+		return diags
+	}
 
-	hasProject, _, err := client.Teams.HasProject(teamID, projectID)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -95,16 +121,15 @@ func resourceRollbarTeamProjectAssociationRead(ctx context.Context, d *schema.Re
 		return diags
 	}
 
-	if !hasProject {
-		return diag.Errorf("could not find project %d on team %d", projectID, teamID)
-	}
-
 	return diags
 }
 
 func resourceRollbarTeamProjectAssociationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := meta.(*Config).API
+	client, clientErr := meta.(*Config).apiClient(ctx)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	result, _ := ParseCompositeID(d.Id(), 2)
 	teamID, _ := strconv.Atoi(result[0])
@@ -37,6 +37,7 @@ resource "rollbar_team" "foobar" {
 
 resource "rollbar_project" "foobar" {
 	name = "%s"
+	deletion_protection = false
 }
 
 resource "rollbar_team_project_association" "foobar" {
@@ -6,9 +6,9 @@ import (
 	"github.com/davidji99/rollrest-go/rollrest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"log"
 	"regexp"
 	"strconv"
+	"time"
 )
 
 const (
@@ -31,6 +31,14 @@ func resourceRollbarTeamUserAssociation() *schema.Resource {
 			StateContext: resourceRollbarTeamUserAssociationImport,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		CustomizeDiff: requireAccountAccessToken("rollbar_team_user_association"),
+
 		Schema: map[string]*schema.Schema{
 			"team_id": {
 				Type:     schema.TypeInt,
@@ -68,7 +76,10 @@ func resourceRollbarTeamUserAssociation() *schema.Resource {
 }
 
 func resourceRollbarTeamUserAssociationImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	client := meta.(*Config).API
+	client, clientErr := meta.(*Config).apiClient(ctx)
+	if clientErr != nil {
+		return nil, clientErr
+	}
 
 	result, parseErr := ParseCompositeID(d.Id(), 2)
 	if parseErr != nil {
@@ -79,7 +90,7 @@ func resourceRollbarTeamUserAssociationImport(ctx context.Context, d *schema.Res
 	email := result[1]
 
 	// Retrieve user ID by email
-	user, _, userFindErr := findUserByEmail(client, email)
+	user, _, userFindErr := findUserByEmail(ctx, meta.(*Config), email)
 	if userFindErr != nil {
 		return nil, fmt.Errorf("did not find an existing Rollbar user with email %s", email)
 	}
@@ -106,13 +117,13 @@ func resourceRollbarTeamUserAssociationImport(ctx context.Context, d *schema.Res
 	return []*schema.ResourceData{d}, nil
 }
 
-func findUserByEmail(client *rollrest.Client, email string) (*rollrest.User, bool, error) {
-	users, _, userInfoErr := client.Users.List()
+func findUserByEmail(ctx context.Context, config *Config, email string) (*rollrest.User, bool, error) {
+	users, userInfoErr := config.listUsers(ctx)
 	if userInfoErr != nil {
 		return nil, false, userInfoErr
 	}
 
-	for _, u := range users.GetResult().Users {
+	for _, u := range users {
 		if u.GetEmail() == email {
 			return u, true, nil
 		}
@@ -127,11 +138,14 @@ func constructTeamUserResourceID(teamID int, email string) string {
 
 func resourceRollbarTeamUserAssociationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := meta.(*Config).API
-	teamID := getTeamID(d)
-	email := getEmail(d)
+	client, clientErr := meta.(*Config).apiClient(ctx)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	teamID := getTeamID(ctx, d)
+	email := getEmail(ctx, d)
 
-	log.Printf("[DEBUG] Inviting or adding %s to team %d", email, teamID)
+	logDebug(ctx, "Inviting or adding user to team", map[string]interface{}{"email": email, "team_id": teamID})
 
 	inviteResponse, _, inviteErr := client.Teams.InviteUser(teamID, &rollrest.TeamInviteRequest{Email: email})
 	if inviteErr != nil {
@@ -143,7 +157,7 @@ func resourceRollbarTeamUserAssociationCreate(ctx context.Context, d *schema.Res
 		return diags
 	}
 
-	log.Printf("[DEBUG] Invited or added %s to team %d", email, teamID)
+	logDebug(ctx, "Invited or added user to team", map[string]interface{}{"email": email, "team_id": teamID})
 
 	var resourceID string
 	var invitedOrAdded string
@@ -173,6 +187,9 @@ func resourceRollbarTeamUserAssociationCreate(ctx context.Context, d *schema.Res
 		return diags
 	}
 
+	// Accepted invitations add new users to the account.
+	meta.(*Config).cache.users.invalidate()
+
 	d.SetId(resourceID)
 
 	// This will either be the actual invitation ID or an empty string
@@ -184,7 +201,10 @@ func resourceRollbarTeamUserAssociationCreate(ctx context.Context, d *schema.Res
 
 func resourceRollbarTeamUserAssociationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := meta.(*Config).API
+	client, clientErr := meta.(*Config).apiClient(ctx)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	result, parseErr := ParseCompositeID(d.Id(), 2)
 	if parseErr != nil {
@@ -205,7 +225,15 @@ func resourceRollbarTeamUserAssociationRead(ctx context.Context, d *schema.Resou
 	d.Set("invitation_status", "")
 
 	if invitedOrAdded == TeamUserAddedStatus || d.Get("invitation_status").(string) == InviteStatusAccepted {
-		user, _, userFindErr := findUserByEmail(client, email)
+		user, userFound, userFindErr := findUserByEmail(ctx, meta.(*Config), email)
+		if userFindErr == nil && !userFound {
+			userFindErr = NotFoundError{fmt.Errorf("could not find user %s", email)}
+		}
+
+		if removeFromStateIfNotFound(ctx, d, "rollbar_team_user_association", nil, userFindErr) {
+			return diags
+		}
+
 		if userFindErr != nil {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
@@ -219,7 +247,11 @@ func resourceRollbarTeamUserAssociationRead(ctx context.Context, d *schema.Resou
 
 	if invitedOrAdded == TeamUserInvitedStatus {
 		inviteID := d.Get("invitation_id").(int)
-		inviteStatus, _, statusErr := client.Invitations.Get(inviteID)
+		inviteStatus, response, statusErr := client.Invitations.Get(inviteID)
+		if removeFromStateIfNotFound(ctx, d, "rollbar_team_user_association", response, statusErr) {
+			return diags
+		}
+
 		if statusErr != nil {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
@@ -243,7 +275,10 @@ func resourceRollbarTeamUserAssociationRead(ctx context.Context, d *schema.Resou
 
 func resourceRollbarTeamUserAssociationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := meta.(*Config).API
+	client, clientErr := meta.(*Config).apiClient(ctx)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	result, parseErr := ParseCompositeID(d.Id(), 2)
 	if parseErr != nil {
@@ -262,19 +297,20 @@ func resourceRollbarTeamUserAssociationDelete(ctx context.Context, d *schema.Res
 	if invitedOrAdded == TeamUserInvitedStatus && d.Get("invitation_status").(string) == InviteStatusPending {
 		inviteID := d.Get("invitation_id").(int)
 
-		log.Printf("[DEBUG] Cancelling invitation %d", inviteID)
+		logDebug(ctx, "Cancelling invitation", map[string]interface{}{"invitation_id": inviteID})
 
 		// Cancel the invitation
 		_, _, cancelErr := client.Invitations.Cancel(d.Get("invitation_id").(int))
 		if cancelErr != nil {
-			log.Printf("[DEBUG] issue cancelling invitation but that's okay as subsequent invitations to the same email address will invalidate any pending ones")
+			logDebug(ctx, "issue cancelling invitation but that's okay as subsequent invitations "+
+				"to the same email address will invalidate any pending ones")
 		}
 
-		log.Printf("[DEBUG] Cancelled invitation %d", inviteID)
+		logDebug(ctx, "Cancelled invitation", map[string]interface{}{"invitation_id": inviteID})
 	}
 
 	if invitedOrAdded == TeamUserAddedStatus || d.Get("invitation_status").(string) == InviteStatusAccepted {
-		log.Printf("[DEBUG] Removing %s from team %d", email, teamID)
+		logDebug(ctx, "Removing user from team", map[string]interface{}{"email": email, "team_id": teamID})
 
 		_, _, removeErr := client.Teams.RemoveUser(teamID, d.Get("user_id").(int))
 		if removeErr != nil {
@@ -286,7 +322,7 @@ func resourceRollbarTeamUserAssociationDelete(ctx context.Context, d *schema.Res
 			return diags
 		}
 
-		log.Printf("[DEBUG] Removed %s from team %d", email, teamID)
+		logDebug(ctx, "Removed user from team", map[string]interface{}{"email": email, "team_id": teamID})
 	}
 
 	d.SetId("")
@@ -0,0 +1,146 @@
+package rollbar
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the default number of times a rate limited or failed request is retried.
+	DefaultMaxRetries = 3
+
+	// DefaultRetryMaxWait is the default maximum number of seconds to wait between retries.
+	DefaultRetryMaxWait = 30
+
+	// DefaultRetryMinWait is the initial wait time before exponential backoff is applied.
+	DefaultRetryMinWait = 1 * time.Second
+
+	// RateLimitRemainingHeader is the header Rollbar uses to return the remaining calls in the current window.
+	RateLimitRemainingHeader = "X-Rate-Limit-Remaining"
+
+	// RateLimitResetHeader is the header Rollbar uses to return the epoch time of when the current window resets.
+	RateLimitResetHeader = "X-Rate-Limit-Reset"
+)
+
+// retryTransport is a http.RoundTripper that retries requests which were rate limited
+// or failed due to a server side error.
+type retryTransport struct {
+	ctx        context.Context
+	transport  http.RoundTripper
+	maxRetries int
+	minWait    time.Duration
+	maxWait    time.Duration
+}
+
+// newRetryTransport returns a retryTransport wrapping the given http.RoundTripper.
+//
+// The context is used for logging retries of requests that were made without a context.
+func newRetryTransport(ctx context.Context, transport http.RoundTripper, maxRetries int, maxWait time.Duration) *retryTransport {
+	return &retryTransport{
+		ctx:        ctx,
+		transport:  transport,
+		maxRetries: maxRetries,
+		minWait:    DefaultRetryMinWait,
+		maxWait:    maxWait,
+	}
+}
+
+// RoundTrip executes the request and retries it on 429 and 5xx responses until maxRetries is reached.
+//
+// Non-idempotent requests are only retried on 429 responses.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := t.transport.RoundTrip(req)
+		if err != nil || attempt >= t.maxRetries || !isRetryableResponse(req, resp) {
+			return resp, err
+		}
+
+		// Requests with a body can only be retried if the body can be read again.
+		if req.Body != nil && req.GetBody == nil {
+			return resp, nil
+		}
+
+		wait := t.waitTime(attempt, resp)
+		logDebug(requestLogContext(req, t.ctx), fmt.Sprintf("%s %s returned %d. Retrying in %s (%d/%d)",
+			req.Method, req.URL.Path, resp.StatusCode, wait, attempt+1, t.maxRetries))
+
+		// Drain and close the body so the underlying connection can be reused.
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		req = req.Clone(req.Context())
+		if req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return nil, bodyErr
+			}
+			req.Body = body
+		}
+	}
+}
+
+// waitTime determines how long to wait before the next attempt.
+//
+// Rate limited requests wait until the rate limit window resets if Rollbar reports no remaining calls.
+// All other retries use an exponential backoff with jitter.
+func (t *retryTransport) waitTime(attempt int, resp *http.Response) time.Duration {
+	if resp.StatusCode == http.StatusTooManyRequests && resp.Header.Get(RateLimitRemainingHeader) == "0" {
+		if reset, parseErr := strconv.ParseInt(resp.Header.Get(RateLimitResetHeader), 10, 64); parseErr == nil {
+			if wait := time.Until(time.Unix(reset, 0)); wait > 0 {
+				return t.capWait(wait)
+			}
+		}
+	}
+
+	backoff := t.capWait(t.minWait << uint(attempt))
+
+	// Apply jitter so parallel requests hitting the same limit do not retry in lockstep.
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
+
+// capWait limits a wait time to maxWait.
+func (t *retryTransport) capWait(wait time.Duration) time.Duration {
+	if wait <= 0 || wait > t.maxWait {
+		return t.maxWait
+	}
+	return wait
+}
+
+// isRetryableResponse returns true if the response was rate limited or a server side error.
+//
+// A server side error can be returned by a gateway after Rollbar already processed the request, so only requests
+// that can safely be repeated are retried on 5xx. Repeating a POST could create a duplicate project, team or token.
+// Rate limited requests were rejected before being processed and can always be retried.
+func isRetryableResponse(req *http.Request, resp *http.Response) bool {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	return resp.StatusCode >= http.StatusInternalServerError && isIdempotentMethod(req.Method)
+}
+
+// isIdempotentMethod returns true for HTTP methods the Rollbar API handles idempotently.
+//
+// PATCH requests to the Rollbar API set fields to the given values, so repeating them has no further effect.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPatch, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,185 @@
+package rollbar
+
+import (
+	"context"
+	"github.com/davidji99/rollrest-go/rollrest"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// throttleTransport is a http.RoundTripper that limits the rate and concurrency of API requests.
+//
+// Requests made with the account access token and each project access token are throttled separately
+// as Rollbar enforces rate limits per access token.
+type throttleTransport struct {
+	transport             http.RoundTripper
+	requestsPerMinute     int
+	maxConcurrentRequests int
+
+	// budgets holds the throttle budget of each access token.
+	budgets   map[string]*throttleBudget
+	budgetsMu sync.Mutex
+}
+
+// throttleBudget holds the rate limiter and concurrency limiter for a single access token.
+type throttleBudget struct {
+	bucket    *tokenBucket
+	semaphore chan struct{}
+}
+
+// newThrottleTransport returns a throttleTransport wrapping the given http.RoundTripper.
+//
+// A requestsPerMinute or maxConcurrentRequests value of zero disables the respective limit.
+func newThrottleTransport(transport http.RoundTripper, requestsPerMinute, maxConcurrentRequests int) *throttleTransport {
+	return &throttleTransport{
+		transport:             transport,
+		requestsPerMinute:     requestsPerMinute,
+		maxConcurrentRequests: maxConcurrentRequests,
+		budgets:               make(map[string]*throttleBudget),
+	}
+}
+
+// RoundTrip waits for the request's budget to allow another request before executing it.
+func (t *throttleTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	budget := t.budget(req)
+
+	if budget.semaphore != nil {
+		select {
+		case budget.semaphore <- struct{}{}:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+
+	release := func() {
+		if budget.semaphore != nil {
+			<-budget.semaphore
+		}
+	}
+
+	if budget.bucket != nil {
+		if waitErr := budget.bucket.wait(req.Context()); waitErr != nil {
+			release()
+			return nil, waitErr
+		}
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	// Hold on to the concurrency slot until the response body has been consumed.
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: release}
+
+	return resp, nil
+}
+
+// budget returns the throttle budget of the access token the request is authenticated with.
+func (t *throttleTransport) budget(req *http.Request) *throttleBudget {
+	token := req.Header.Get(rollrest.RollbarAuthHeader)
+
+	t.budgetsMu.Lock()
+	defer t.budgetsMu.Unlock()
+
+	if budget, ok := t.budgets[token]; ok {
+		return budget
+	}
+
+	budget := &throttleBudget{}
+
+	if t.requestsPerMinute > 0 {
+		budget.bucket = newTokenBucket(t.requestsPerMinute)
+	}
+
+	if t.maxConcurrentRequests > 0 {
+		budget.semaphore = make(chan struct{}, t.maxConcurrentRequests)
+	}
+
+	t.budgets[token] = budget
+
+	return budget
+}
+
+// releaseOnClose calls release once when the wrapped body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+// Close closes the wrapped body and releases the concurrency slot.
+func (r *releaseOnClose) Close() error {
+	err := r.ReadCloser.Close()
+	r.once.Do(r.release)
+	return err
+}
+
+// tokenBucket is a token bucket rate limiter.
+//
+// The bucket holds up to one second worth of requests so calls are spread evenly across the minute
+// instead of being sent in a single burst.
+type tokenBucket struct {
+	mu       sync.Mutex
+	tokens   float64
+	capacity float64
+	rate     float64
+	last     time.Time
+}
+
+// newTokenBucket returns a full tokenBucket refilling at requestsPerMinute.
+func newTokenBucket(requestsPerMinute int) *tokenBucket {
+	capacity := float64(requestsPerMinute) / 60
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &tokenBucket{
+		tokens:   capacity,
+		capacity: capacity,
+		rate:     float64(requestsPerMinute) / 60,
+		last:     time.Now(),
+	}
+}
+
+// wait blocks until a token is available or the context is done.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		wait := b.take()
+		if wait == 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// take removes a token from the bucket. If no token is available,
+// it returns how long to wait until the next token is added.
+func (b *tokenBucket) take() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.capacity {
+		b.tokens = b.capacity
+	}
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
@@ -0,0 +1,73 @@
+package rollbar
+
+import (
+	"github.com/davidji99/rollrest-go/rollrest"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket_Rate(t *testing.T) {
+	bucket := newTokenBucket(60)
+
+	assert.Equal(t, time.Duration(0), bucket.take())
+	assert.InDelta(t, float64(time.Second), float64(bucket.take()), float64(50*time.Millisecond))
+}
+
+func TestThrottleTransport_MaxConcurrentRequests(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			observed := atomic.LoadInt32(&maxInFlight)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newThrottleTransport(http.DefaultTransport, 0, 2)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL)
+			if assert.Nil(t, err) {
+				resp.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), maxInFlight)
+}
+
+func TestThrottleTransport_SeparateBudgets(t *testing.T) {
+	transport := newThrottleTransport(http.DefaultTransport, 60, 0)
+
+	accountReq, _ := http.NewRequest(http.MethodGet, "https://example.com", nil)
+	accountReq.Header.Set(rollrest.RollbarAuthHeader, "account-token")
+
+	projectReq, _ := http.NewRequest(http.MethodGet, "https://example.com", nil)
+	projectReq.Header.Set(rollrest.RollbarAuthHeader, "project-token")
+
+	accountBudget := transport.budget(accountReq)
+	projectBudget := transport.budget(projectReq)
+
+	assert.Same(t, accountBudget, transport.budget(accountReq))
+	assert.NotSame(t, accountBudget, projectBudget)
+
+	// Exhausting the account token budget must not affect the project token budget.
+	assert.Equal(t, time.Duration(0), accountBudget.bucket.take())
+	assert.NotEqual(t, time.Duration(0), accountBudget.bucket.take())
+	assert.Equal(t, time.Duration(0), projectBudget.bucket.take())
+}